@@ -0,0 +1,36 @@
+// Package main serves an in-memory fake of the Watchlist API for local development.
+//
+// Point the bot's API_HOST at the printed address and use the same API_SECRET for both processes.
+// All data is kept in memory and lost when the process stops.
+//
+// Usage:
+//
+//	go run ./cmd/fakeapi -addr :8081 -secret SECRETEXAMPLE
+package main
+
+import (
+	"flag"
+	"github.com/k4sper1love/watchlist-bot/internal/fakeapi"
+	"log/slog"
+	"net/http"
+	"os"
+)
+
+// main parses the flags and serves the fake API until the process is stopped.
+// The secret defaults to the API_SECRET environment variable used by the bot.
+func main() {
+	addr := flag.String("addr", ":8081", "address to listen on")
+	secret := flag.String("secret", os.Getenv("API_SECRET"), "secret used to verify Telegram verification tokens")
+	flag.Parse()
+
+	if *secret == "" {
+		slog.Error("secret is required: set -secret or API_SECRET")
+		os.Exit(1)
+	}
+
+	slog.Info("fake watchlist API started", slog.String("addr", *addr))
+	if err := http.ListenAndServe(*addr, fakeapi.New(fakeapi.Options{Secret: *secret})); err != nil {
+		slog.Error("fake watchlist API stopped", slog.Any("error", err))
+		os.Exit(1)
+	}
+}
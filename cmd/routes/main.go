@@ -0,0 +1,19 @@
+// Package main prints all update routes of the Watchlist bot together with the roles they require.
+//
+// It is intended for auditing which commands, callbacks and input states are available to each role.
+package main
+
+import (
+	"fmt"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers"
+	"os"
+)
+
+// main registers the routes and prints the listing, exiting with an error if the routes overlap.
+func main() {
+	if err := handlers.InitRoutes(); err != nil {
+		os.Exit(1)
+	}
+
+	fmt.Print(handlers.RouteListing())
+}
@@ -5,24 +5,41 @@
 package bot
 
 import (
+	"context"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/k4sper1love/watchlist-api/pkg/logger/sl"
 	"github.com/k4sper1love/watchlist-bot/internal/config"
 	"github.com/k4sper1love/watchlist-bot/internal/database/postgres"
 	"github.com/k4sper1love/watchlist-bot/internal/handlers"
+	"github.com/k4sper1love/watchlist-bot/internal/messenger"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/watchlist"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"github.com/k4sper1love/watchlist-bot/pkg/httpclient"
 	"github.com/k4sper1love/watchlist-bot/pkg/logger"
 	"github.com/k4sper1love/watchlist-bot/pkg/translator"
 	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
+// stopFunc stops the delivery of new updates.
+type stopFunc func(ctx context.Context) error
+
+// cancelGracePeriod is how long canceled handlers are given to return before the resources they use are closed.
+const cancelGracePeriod = 5 * time.Second
+
 // Run initializes and starts the bot application.
 // It loads the configuration, sets up logging, connects to the database,
-// initializes the translator, and starts the bot.
+// initializes the translator, and starts the bot until SIGINT or SIGTERM is received.
 func Run() error {
 	slog.Info("starting application...")
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Load application configuration
 	app, err := config.Init()
 	if err != nil {
@@ -33,25 +50,80 @@ func Run() error {
 	// Initialize structured logging
 	sl.Init(app.Config.Environment)
 
+	// Configure the shared HTTP clients of external services
+	configureHTTPClients(app.Config)
+
 	// Connect to the PostgreSQL database
 	if err = postgres.ConnectDatabase(app.Config.DatabaseURL); err != nil {
 		return err
 	}
+	defer closeResources()
 	slog.Info("database connection established successfully")
 
+	// Initialize the Watchlist API client
+	watchlist.Init(watchlist.NewWatchlistClient(watchlist.Options{
+		Context:          ctx,
+		BaseURL:          app.Config.APIHost,
+		APISecret:        app.Config.APISecret,
+		CacheTTL:         app.Config.ListCacheTTL,
+		FailureThreshold: app.Config.WatchlistBreakerThreshold,
+		OpenTimeout:      app.Config.WatchlistBreakerTimeout,
+		SaveTokens:       postgres.SaveSessionTokens,
+		OnAvailability:   func(up bool) { notifyAdmins(app, up) },
+	}))
+
 	// Initialize the translator with locale directory
 	if err = translator.Init(app.Config.LocalesDir); err != nil {
 		return err
 	}
 	slog.Info("translator initialized successfully")
 
+	// Register the update routes of all handlers
+	if err = handlers.InitRoutes(); err != nil {
+		return err
+	}
+	slog.Info("update routes registered successfully")
+
 	// Start the Telegram bot
-	return startBot(app)
+	return startBot(ctx, app)
+}
+
+// configureHTTPClients applies the configured timeouts and retry settings to the shared HTTP clients.
+func configureHTTPClients(config *models.Config) {
+	timeouts := map[string]time.Duration{
+		httpclient.Watchlist: config.WatchlistTimeout,
+		httpclient.Kinopoisk: config.KinopoiskTimeout,
+		httpclient.External:  config.ExternalTimeout,
+		httpclient.Images:    config.ImageTimeout,
+		httpclient.Public:    config.ExternalTimeout,
+	}
+
+	for service, timeout := range timeouts {
+		httpclient.Configure(service, httpclient.Options{
+			Timeout:        timeout,
+			MaxAttempts:    config.HTTPMaxAttempts,
+			RetryBaseDelay: config.HTTPRetryBaseDelay,
+		})
+	}
+}
+
+// closeResources closes the database connection and the per-user log files.
+func closeResources() {
+	if err := postgres.CloseDatabase(); err != nil {
+		slog.Error("failed to close database connection", slog.Any("error", err))
+	}
+
+	if err := logger.Close(); err != nil {
+		slog.Error("failed to close log files", slog.Any("error", err))
+	}
+
+	slog.Info("resources closed")
 }
 
 // startBot initializes the Telegram bot API and starts processing updates.
-// It authorizes the bot, fetches updates, and processes them in a loop.
-func startBot(app *models.App) error {
+// It authorizes the bot, fetches updates, and processes them until the context is canceled,
+// then stops receiving updates and waits for running handlers to finish.
+func startBot(ctx context.Context, app *models.App) error {
 	bot, err := tgbotapi.NewBotAPI(app.Config.BotToken)
 	if err != nil {
 		slog.Error("failed to create bot", slog.Any("error", err))
@@ -59,43 +131,137 @@ func startBot(app *models.App) error {
 	}
 
 	bot.Debug = false
-	app.Bot = bot
+	app.Messenger = messenger.NewTelegram(bot)
 	slog.Info("bot authorized", slog.String("username", bot.Self.UserName))
 
-	updates, err := fetchUpdates(bot)
+	updates, stopUpdates, err := fetchUpdates(app.Config, bot)
 	if err != nil {
 		return err
 	}
 
-	processUpdates(app, updates)
+	// Handlers get their own context so that they keep running after the signal
+	// and are only aborted if they do not finish within the shutdown timeout.
+	handlersCtx, cancelHandlers := context.WithCancel(context.Background())
+	defer cancelHandlers()
+	app.Ctx = handlersCtx
+
+	d := newDispatcher(app.Config, handlers.HandleUpdates)
+	processUpdates(ctx, app, updates, d)
+
+	return shutdown(app, updates, stopUpdates, d, cancelHandlers)
+}
+
+// shutdown stops receiving updates, dispatches the updates already received and waits for the dispatched handlers,
+// including broadcasts, to finish within the configured timeout. Handlers still running after the timeout are canceled
+// and given a short grace period to return before the resources they use are closed.
+func shutdown(app *models.App, updates tgbotapi.UpdatesChannel, stopUpdates stopFunc, d *dispatcher, cancelHandlers context.CancelFunc) error {
+	slog.Info("shutting down...", slog.String("timeout", app.Config.ShutdownTimeout.String()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), app.Config.ShutdownTimeout)
+	defer cancel()
+
+	if err := stopUpdates(ctx); err != nil {
+		slog.Error("failed to stop receiving updates", slog.Any("error", err))
+	}
+
+	// Telegram considers the buffered updates delivered, so they would be lost if they were not handled.
+	drainUpdates(app, updates, d)
+
+	if err := d.shutdown(ctx); err != nil {
+		slog.Error("running handlers did not finish in time", slog.Any("error", err))
+		cancelHandlers()
+		waitCanceledHandlers(d)
+		return err
+	}
+
+	slog.Info("all running handlers finished")
 	return nil
 }
 
-// fetchUpdates retrieves updates from the Telegram bot API.
-// It configures the update channel with a timeout and returns the channel for processing.
-func fetchUpdates(bot *tgbotapi.BotAPI) (tgbotapi.UpdatesChannel, error) {
+// waitCanceledHandlers waits for the canceled handlers to return within the grace period.
+func waitCanceledHandlers(d *dispatcher) {
+	ctx, cancel := context.WithTimeout(context.Background(), cancelGracePeriod)
+	defer cancel()
+
+	if err := d.wait(ctx); err != nil {
+		slog.Warn("canceled handlers did not return in time", slog.Any("error", err))
+	}
+}
+
+// fetchUpdates retrieves updates from the Telegram bot API using the configured delivery mode.
+// Long polling is used by default, while webhook mode starts an HTTP server receiving updates.
+func fetchUpdates(config *models.Config, bot *tgbotapi.BotAPI) (tgbotapi.UpdatesChannel, stopFunc, error) {
+	if config.UpdatesMode == "webhook" {
+		return fetchWebhookUpdates(config, bot)
+	}
+	return fetchPollingUpdates(bot)
+}
+
+// fetchPollingUpdates retrieves updates from the Telegram bot API via long polling.
+// It removes any registered webhook, configures the update channel with a timeout and returns it for processing.
+func fetchPollingUpdates(bot *tgbotapi.BotAPI) (tgbotapi.UpdatesChannel, stopFunc, error) {
+	if err := deleteWebhook(bot); err != nil {
+		return nil, nil, err
+	}
+
 	updateConfig := tgbotapi.NewUpdate(0)
 	updateConfig.Timeout = 60
 
 	updates, err := bot.GetUpdatesChan(updateConfig)
 	if err != nil {
 		slog.Error("failed to get updates", slog.Any("error", err))
-		return nil, err
+		return nil, nil, err
+	}
+
+	stop := func(context.Context) error {
+		bot.StopReceivingUpdates()
+		return nil
+	}
+	return updates, stop, nil
+}
+
+// processUpdates processes incoming updates from the Telegram bot API until the context is canceled.
+// It filters out bot messages and delegates handling to per-user workers of the dispatcher.
+func processUpdates(ctx context.Context, app *models.App, updates tgbotapi.UpdatesChannel, d *dispatcher) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			dispatchUpdate(app, &update, d)
+		}
 	}
-	return updates, nil
 }
 
-// processUpdates processes incoming updates from the Telegram bot API.
-// It filters out bot messages and delegates handling to the appropriate handlers.
-func processUpdates(app *models.App, updates tgbotapi.UpdatesChannel) {
-	for update := range updates {
-		if utils.IsBotMessage(&update) {
-			continue
+// drainUpdates dispatches the updates left in the channel after receiving has stopped,
+// until the channel is empty or closed.
+func drainUpdates(app *models.App, updates tgbotapi.UpdatesChannel, d *dispatcher) {
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			dispatchUpdate(app, &update, d)
+
+		default:
+			return
 		}
+	}
+}
 
-		updateAppContext(app, &update)
-		go handlers.HandleUpdates(*app)
+// dispatchUpdate delegates the update to the dispatcher, skipping messages sent by bots.
+func dispatchUpdate(app *models.App, update *tgbotapi.Update, d *dispatcher) {
+	if utils.IsBotMessage(update) {
+		return
 	}
+
+	updateAppContext(app, update)
+	d.dispatch(*app)
 }
 
 // updateAppContext updates the application context based on the current Telegram update.
@@ -103,7 +269,7 @@ func processUpdates(app *models.App, updates tgbotapi.UpdatesChannel) {
 func updateAppContext(app *models.App, update *tgbotapi.Update) {
 	userID := utils.ParseTelegramID(update)
 
-	if userID != app.Bot.Self.ID {
+	if userID != app.Messenger.Self().ID {
 		app.Logger = logger.Get(userID)
 	}
 
@@ -0,0 +1,146 @@
+package bot
+
+import (
+	"context"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// dispatcher routes updates to per-user workers so that updates from the same user
+// are handled sequentially in arrival order, while different users are served in parallel.
+type dispatcher struct {
+	handle      func(models.App) // Function that handles a single update.
+	workers     map[int]*worker  // Active workers keyed by Telegram ID.
+	mu          sync.Mutex       // Mutex guarding the workers map and pending counters.
+	slots       chan struct{}    // Semaphore limiting the number of concurrently running handlers.
+	queueSize   int              // Capacity of each per-user queue.
+	idleTimeout time.Duration    // Idle period after which a worker is evicted.
+	wg          sync.WaitGroup   // Tracks running workers.
+}
+
+// worker processes the queued updates of a single user.
+type worker struct {
+	queue   chan models.App // Pending updates of the user.
+	pending int             // Number of updates dispatched but not yet handled.
+}
+
+// newDispatcher creates a dispatcher using the concurrency settings from the configuration.
+func newDispatcher(config *models.Config, handle func(models.App)) *dispatcher {
+	return &dispatcher{
+		handle:      handle,
+		workers:     make(map[int]*worker),
+		slots:       make(chan struct{}, config.MaxConcurrentUpdates),
+		queueSize:   config.UserQueueSize,
+		idleTimeout: config.WorkerIdleTimeout,
+	}
+}
+
+// dispatch enqueues the update to the worker of its sender, starting the worker if needed.
+// It blocks while the user's queue is full, applying back-pressure to the update source.
+func (d *dispatcher) dispatch(app models.App) {
+	telegramID := utils.ParseTelegramID(app.Update)
+
+	d.mu.Lock()
+	w, ok := d.workers[telegramID]
+	if !ok {
+		w = &worker{queue: make(chan models.App, d.queueSize)}
+		d.workers[telegramID] = w
+		d.wg.Add(1)
+		go d.run(telegramID, w)
+	}
+	w.pending++
+	d.mu.Unlock()
+
+	select {
+	case w.queue <- app:
+	default:
+		slog.Warn("user queue is full, waiting", slog.Int("telegram_id", telegramID))
+		w.queue <- app
+	}
+}
+
+// run handles the worker's updates one by one.
+// The worker stops after it stays idle for too long or once its queue is closed and drained.
+func (d *dispatcher) run(telegramID int, w *worker) {
+	defer d.wg.Done()
+
+	timer := time.NewTimer(d.idleTimeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case app, ok := <-w.queue:
+			if !ok {
+				return
+			}
+			d.process(app)
+
+			d.mu.Lock()
+			w.pending--
+			d.mu.Unlock()
+
+			timer.Reset(d.idleTimeout)
+
+		case <-timer.C:
+			if d.evict(telegramID, w) {
+				return
+			}
+			timer.Reset(d.idleTimeout)
+		}
+	}
+}
+
+// process handles a single update while holding a global concurrency slot.
+func (d *dispatcher) process(app models.App) {
+	d.slots <- struct{}{}
+	defer func() { <-d.slots }()
+
+	d.handle(app)
+}
+
+// evict removes the worker from the dispatcher if no updates are pending for it.
+func (d *dispatcher) evict(telegramID int, w *worker) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if w.pending > 0 {
+		return false
+	}
+
+	if d.workers[telegramID] == w {
+		delete(d.workers, telegramID)
+	}
+	return true
+}
+
+// shutdown stops accepting updates and waits until all queued updates are handled.
+// It must be called after dispatching has stopped and returns an error if the context expires first.
+func (d *dispatcher) shutdown(ctx context.Context) error {
+	d.mu.Lock()
+	for telegramID, w := range d.workers {
+		close(w.queue)
+		delete(d.workers, telegramID)
+	}
+	d.mu.Unlock()
+
+	return d.wait(ctx)
+}
+
+// wait waits for all workers to stop or for the context to be done, whichever happens first.
+func (d *dispatcher) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
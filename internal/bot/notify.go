@@ -0,0 +1,31 @@
+package bot
+
+import (
+	"github.com/k4sper1love/watchlist-bot/internal/builders/messages"
+	"github.com/k4sper1love/watchlist-bot/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"log/slog"
+)
+
+// notifyAdmins informs all admins that the Watchlist API became unavailable or available again.
+// Notifications are skipped until the bot is authorized and can send messages.
+func notifyAdmins(app *models.App, up bool) {
+	if app.Messenger == nil {
+		return
+	}
+
+	admins, err := postgres.GetUsers(true)
+	if err != nil {
+		slog.Error("failed to get admins for notification", slog.Any("error", err))
+		return
+	}
+
+	for i := range admins {
+		admin := &admins[i]
+		if up {
+			app.SendMessageByID(admin.TelegramID, messages.ServiceRecovered(admin), nil)
+		} else {
+			app.SendMessageByID(admin.TelegramID, messages.ServiceDown(admin), nil)
+		}
+	}
+}
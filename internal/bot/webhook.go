@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// secretTokenHeader is the header Telegram uses to pass the secret token configured for the webhook.
+const secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// fetchWebhookUpdates registers the webhook with Telegram and starts an HTTP server receiving updates.
+// It returns a channel fed by the server, compatible with the long polling channel,
+// and a function that shuts the server down.
+func fetchWebhookUpdates(config *models.Config, bot *tgbotapi.BotAPI) (tgbotapi.UpdatesChannel, stopFunc, error) {
+	webhookURL, err := url.Parse(config.WebhookURL)
+	if err != nil {
+		slog.Error("failed to parse webhook URL", slog.Any("error", err))
+		return nil, nil, err
+	}
+
+	listener, err := net.Listen("tcp", config.WebhookListenAddr)
+	if err != nil {
+		slog.Error("failed to listen for webhook", slog.Any("error", err), slog.String("addr", config.WebhookListenAddr))
+		return nil, nil, err
+	}
+
+	if err = setWebhook(config, bot); err != nil {
+		_ = listener.Close()
+		return nil, nil, err
+	}
+
+	updates := make(chan tgbotapi.Update, bot.Buffer)
+	done := make(chan struct{})
+
+	mux := http.NewServeMux()
+	mux.Handle(webhookPath(webhookURL), webhookHandler(config.WebhookSecret, updates, done))
+	server := &http.Server{Handler: mux}
+
+	go serveWebhook(server, listener, config)
+
+	slog.Info("webhook server started", slog.String("addr", config.WebhookListenAddr), slog.String("path", webhookPath(webhookURL)))
+
+	stop := func(ctx context.Context) error {
+		close(done)
+		return server.Shutdown(ctx)
+	}
+	return updates, stop, nil
+}
+
+// serveWebhook serves webhook requests on the listener, using TLS if a certificate is configured.
+func serveWebhook(server *http.Server, listener net.Listener, config *models.Config) {
+	var err error
+	if config.WebhookCertFile != "" {
+		err = server.ServeTLS(listener, config.WebhookCertFile, config.WebhookKeyFile)
+	} else {
+		err = server.Serve(listener)
+	}
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("webhook server stopped", slog.Any("error", err))
+	}
+}
+
+// webhookHandler returns an HTTP handler that validates the secret token and forwards updates to the channel.
+// Once done is closed, updates are rejected so that Telegram redelivers them after a restart.
+func webhookHandler(secret string, updates chan<- tgbotapi.Update, done <-chan struct{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(secretTokenHeader)), []byte(secret)) != 1 {
+			slog.Warn("rejected webhook request with invalid secret token", slog.String("remote_addr", r.RemoteAddr))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var update tgbotapi.Update
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			slog.Warn("failed to decode webhook update", slog.Any("error", err))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		select {
+		case updates <- update:
+			w.WriteHeader(http.StatusOK)
+		case <-done:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}
+}
+
+// setWebhook registers the webhook URL and secret token with Telegram.
+// If a TLS certificate is configured, it is uploaded so Telegram can trust self-signed certificates.
+func setWebhook(config *models.Config, bot *tgbotapi.BotAPI) error {
+	var err error
+	if config.WebhookCertFile != "" {
+		params := map[string]string{"url": config.WebhookURL, "secret_token": config.WebhookSecret}
+		_, err = bot.UploadFile("setWebhook", params, "certificate", config.WebhookCertFile)
+	} else {
+		params := url.Values{"url": {config.WebhookURL}, "secret_token": {config.WebhookSecret}}
+		_, err = bot.MakeRequest("setWebhook", params)
+	}
+
+	if err != nil {
+		slog.Error("failed to set webhook", slog.Any("error", err))
+		return fmt.Errorf("failed to set webhook: %w", err)
+	}
+
+	slog.Info("webhook registered")
+	return nil
+}
+
+// deleteWebhook removes a previously registered webhook so that updates can be received via long polling.
+func deleteWebhook(bot *tgbotapi.BotAPI) error {
+	if _, err := bot.MakeRequest("deleteWebhook", url.Values{}); err != nil {
+		slog.Error("failed to delete webhook", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+// webhookPath returns the path part of the webhook URL, defaulting to the root path.
+func webhookPath(webhookURL *url.URL) string {
+	if webhookURL.Path == "" {
+		return "/"
+	}
+	return webhookURL.Path
+}
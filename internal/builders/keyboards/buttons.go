@@ -5,6 +5,7 @@ import (
 	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
 	"github.com/k4sper1love/watchlist-bot/internal/handlers/states"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/parsing"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
 	"github.com/k4sper1love/watchlist-bot/pkg/translator"
 	"strconv"
@@ -120,6 +121,14 @@ func (k *Keyboard) AddFilmViewed() *Keyboard {
 	return k.AddButton("✔️", "viewed", states.CallFilmDetailViewed, "", true)
 }
 
+// AddNextEpisode adds a button to mark the next episode of a series as watched, labeled with the episode (e.g., "S2E4").
+func (k *Keyboard) AddNextEpisode(session *models.Session, series *parsing.Series) *Keyboard {
+	next := *series
+	next.NextEpisode()
+	text := fmt.Sprintf("%s: %s", translator.Translate(session.Lang, "nextEpisode", nil, nil), next.FormatEpisode())
+	return k.AddButton("⏭", text, states.CallFilmDetailEpisode, "", false)
+}
+
 // AddFilmSelect adds buttons for selecting films from the current page.
 func (k *Keyboard) AddFilmSelect(session *models.Session) *Keyboard {
 	var buttons []Button
@@ -203,6 +212,11 @@ func (k *Keyboard) AddNewFilmFind() *Keyboard {
 	return k.AddButton("", "findFilm", states.CallNewFilmFind, "", true)
 }
 
+// AddNewFilmFindAnime adds a button to find a new anime.
+func (k *Keyboard) AddNewFilmFindAnime() *Keyboard {
+	return k.AddButton("", "findAnime", states.CallNewFilmFindAnime, "", true)
+}
+
 // AddAgain adds a button to repeat an action.
 func (k *Keyboard) AddAgain(callback string) *Keyboard {
 	return k.AddButton("↻", "again", callback, "", true)
@@ -221,6 +235,34 @@ func (k *Keyboard) AddResetFilmsFilter(session *models.Session, filterType strin
 	return k
 }
 
+// AddResetSearchFilter adds a button to reset a specific search filter if it is active.
+func (k *Keyboard) AddResetSearchFilter(session *models.Session, field string) *Keyboard {
+	if session.FilmsState.SearchFilters.IsFieldEnabled(field) {
+		return k.AddButton("🔄", "reset", states.CallProcessReset, "", true)
+	}
+	return k
+}
+
+// AddSearchFilters adds removable chips of the active search filters and a button to refine the search.
+// They are only added to Kinopoisk searches, as the other services do not support search filters.
+func (k *Keyboard) AddSearchFilters(session *models.Session) *Keyboard {
+	if !session.IsKinopoiskSearch() {
+		return k
+	}
+
+	searchFilters := &session.FilmsState.SearchFilters
+
+	var chips []Button
+	for _, field := range models.SearchFilterFields {
+		if searchFilters.IsFieldEnabled(field) {
+			chips = append(chips, Button{"✖️", formatSearchFilter(searchFilters, session.Lang, field), states.SearchFiltersRemove + field, "", false})
+		}
+	}
+
+	return k.AddButtonsWithRowSize(2, chips...).
+		AddButton("⚙️", "refineSearch", states.CallSearchFilters, "", true)
+}
+
 // AddFavorite adds a button to toggle a film's favorite status.
 func (k *Keyboard) AddFavorite(isFavorite bool, callback string) *Keyboard {
 	messageCode := "makeFavorite"
@@ -479,6 +521,29 @@ func getFiltersFilmsButtons(filter *models.FilmFilters, lang string) []Button {
 	return buttons
 }
 
+// getSearchFiltersButtons generates buttons for the search filters of new films.
+func getSearchFiltersButtons(searchFilters *models.SearchFilters, lang string) []Button {
+	buttons := make([]Button, 0, len(models.SearchFilterFields))
+	for _, field := range models.SearchFilterFields {
+		text := translator.Translate(lang, field, nil, nil)
+		if searchFilters.IsFieldEnabled(field) {
+			text = formatSearchFilter(searchFilters, lang, field)
+		}
+		buttons = append(buttons, Button{utils.BoolToEmoji(searchFilters.IsFieldEnabled(field)), text, states.SearchFiltersSelect + field, "", false})
+	}
+	return buttons
+}
+
+// formatSearchFilter formats a search filter with its label and value, such as "Year: 2000-2010".
+// The type of films is translated, as it is stored as a Kinopoisk type.
+func formatSearchFilter(searchFilters *models.SearchFilters, lang, field string) string {
+	value := searchFilters.String(field)
+	if field == "filmType" {
+		value = translator.Translate(lang, value, nil, nil)
+	}
+	return fmt.Sprintf("%s: %s", translator.Translate(lang, field, nil, nil), value)
+}
+
 // getSortingFilmsButtons generates buttons for film sorting options.
 func getSortingFilmsButtons(sorting *models.Sorting, lang string) []Button {
 	var buttons []Button
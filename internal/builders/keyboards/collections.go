@@ -26,6 +26,15 @@ func Collections(session *models.Session, currentPage, lastPage int) *tgbotapi.I
 		Build(session.Lang)
 }
 
+// CollectionsReadOnly creates an inline keyboard for browsing cached collections while the Watchlist service is unavailable.
+// It only allows opening the collections and going back.
+func CollectionsReadOnly(session *models.Session) *tgbotapi.InlineKeyboardMarkup {
+	return New().
+		AddCollectionsSelect(session).
+		AddBack("").
+		Build(session.Lang)
+}
+
 // CollectionManage creates an inline keyboard for managing a specific collection.
 func CollectionManage(session *models.Session) *tgbotapi.InlineKeyboardMarkup {
 	return New().
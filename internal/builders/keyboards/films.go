@@ -4,6 +4,7 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/k4sper1love/watchlist-bot/internal/handlers/states"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/parsing"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
 )
 
@@ -17,6 +18,8 @@ var updateFilmButtons = []Button{
 	{"", "yearOfRelease", states.CallUpdateFilmYear, "", true},
 	{"", "comment", states.CallUpdateFilmComment, "", true},
 	{"", "viewed", states.CallUpdateFilmViewed, "", true},
+	{"", "seasons", states.CallUpdateFilmSeasons, "", true},
+	{"", "watchedEpisode", states.CallUpdateFilmWatched, "", true},
 }
 
 // Predefined buttons for updating film details after marking it as viewed.
@@ -47,6 +50,20 @@ func Films(session *models.Session, currentPage, lastPage int) *tgbotapi.InlineK
 		Build(session.Lang)
 }
 
+// FilmsReadOnly creates an inline keyboard for browsing cached films while the Watchlist service is unavailable.
+// It only allows opening the films and going back.
+func FilmsReadOnly(session *models.Session) *tgbotapi.InlineKeyboardMarkup {
+	return New().
+		AddFilmSelect(session).
+		AddIf(session.Context == states.CtxFilm, func(k *Keyboard) {
+			k.AddBack("")
+		}).
+		AddIf(session.Context == states.CtxCollection, func(k *Keyboard) {
+			k.AddBack(states.CallFilmsBack)
+		}).
+		Build(session.Lang)
+}
+
 // FindFilms creates an inline keyboard for selecting films with navigation and back options.
 func FindFilms(session *models.Session, currentPage, lastPage int) *tgbotapi.InlineKeyboardMarkup {
 	return New().
@@ -61,18 +78,24 @@ func FindNewFilm(session *models.Session, currentPage, lastPage int) *tgbotapi.I
 	return New().
 		AddFindNewFilmSelect(session).
 		AddNavigation(currentPage, lastPage, states.FindNewFilmPage, true).
+		AddSearchFilters(session).
 		AddBack(states.CallFindNewFilmBack).
 		Build(session.Lang)
 }
 
 // FilmDetail creates an inline keyboard for managing a specific film's details.
+// A series that has not been viewed or watched to the end also gets a button to mark its next episode as watched.
 func FilmDetail(session *models.Session) *tgbotapi.InlineKeyboardMarkup {
 	film := session.FilmDetailState.Film
+	series, isSeries := parsing.ParseSeries(film.Description)
 	return New().
 		AddFavorite(film.IsFavorite, states.CallFilmDetailFavorite).
 		AddIf(film.URL != "", func(k *Keyboard) {
 			k.AddOpenInBrowser(film.URL)
 		}).
+		AddIf(isSeries && !film.IsViewed && !series.IsFinished(), func(k *Keyboard) {
+			k.AddNextEpisode(session, series)
+		}).
 		AddIf(!film.IsViewed, func(k *Keyboard) {
 			k.AddFilmViewed()
 		}).
@@ -106,6 +129,7 @@ func FilmNew(session *models.Session) *tgbotapi.InlineKeyboardMarkup {
 		AddNewFilmManually().
 		AddNewFilmFromURL().
 		AddNewFilmFind().
+		AddNewFilmFindAnime().
 		AddBack(states.CallNewFilmBack).
 		Build(session.Lang)
 }
@@ -178,3 +202,47 @@ func FilmFilterRange(session *models.Session, filterType string) *tgbotapi.Inlin
 		AddCancel().
 		Build(session.Lang)
 }
+
+// NewFilmFind creates an inline keyboard for entering the title of a new film to search for.
+// Kinopoisk searches can be refined before entering the title.
+func NewFilmFind(session *models.Session) *tgbotapi.InlineKeyboardMarkup {
+	return New().
+		AddSearchFilters(session).
+		AddCancel().
+		Build(session.Lang)
+}
+
+// SearchFilters creates an inline keyboard for selecting the search filters of new films.
+func SearchFilters(session *models.Session) *tgbotapi.InlineKeyboardMarkup {
+	searchFilters := &session.FilmsState.SearchFilters
+	return New().
+		AddButtons(getSearchFiltersButtons(searchFilters, session.Lang)...).
+		AddIf(searchFilters.IsEnabled(), func(k *Keyboard) {
+			k.AddButton("🔄", "resetFilters", states.CallSearchFiltersAllReset, "", true)
+		}).
+		AddButton("🔎", "search", states.CallSearchFiltersSearch, "", true).
+		AddBack("").
+		Build(session.Lang)
+}
+
+// SearchFilterType creates an inline keyboard for choosing the type of searched films.
+func SearchFilterType(session *models.Session) *tgbotapi.InlineKeyboardMarkup {
+	buttons := make([]Button, 0, len(models.SearchFilterTypes))
+	for _, filmType := range models.SearchFilterTypes {
+		buttons = append(buttons, Button{"", filmType, states.SearchFiltersType + filmType, "", true})
+	}
+
+	return New().
+		AddButtonsWithRowSize(2, buttons...).
+		AddResetSearchFilter(session, "filmType").
+		AddCancel().
+		Build(session.Lang)
+}
+
+// SearchFilterInput creates an inline keyboard for entering the value of a specific search filter.
+func SearchFilterInput(session *models.Session, field string) *tgbotapi.InlineKeyboardMarkup {
+	return New().
+		AddResetSearchFilter(session, field).
+		AddCancel().
+		Build(session.Lang)
+}
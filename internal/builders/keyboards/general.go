@@ -29,6 +29,16 @@ func Cancel(session *models.Session) *tgbotapi.InlineKeyboardMarkup {
 	return New().AddCancel().Build(session.Lang)
 }
 
+// KinopoiskTokenConfirm creates an inline keyboard for testing, saving or discarding a received Kinopoisk token.
+func KinopoiskTokenConfirm(session *models.Session, testCallback, saveCallback, cancelCallback string) *tgbotapi.InlineKeyboardMarkup {
+	return New().
+		AddButtonsWithRowSize(2,
+			Button{"🧪", "testToken", testCallback, "", true},
+			Button{"💾", "saveToken", saveCallback, "", true}).
+		AddButton("", "cancel", cancelCallback, "", true).
+		Build(session.Lang)
+}
+
 // Back creates an inline keyboard with a back button.
 func Back(session *models.Session, callback string) *tgbotapi.InlineKeyboardMarkup {
 	return New().AddBack(callback).Build(session.Lang)
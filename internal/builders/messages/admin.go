@@ -61,7 +61,7 @@ func FeedbackDetail(session *models.Session, feedback *models.Feedback) string {
 		toBold(translator.Translate(session.Lang, "feedbackDetails", nil, nil)),
 		toBold("Telegram ID"),
 		toCode(strconv.Itoa(feedback.TelegramID)),
-		formatOptionalString(toBold("Username"), feedback.TelegramUsername, "%s: @%s\n"),
+		formatOptionalString(toBold("Username"), escape(feedback.TelegramUsername), "%s: @%s\n"),
 		toBold(translator.Translate(session.Lang, "category", nil, nil)),
 		translator.Translate(session.Lang, feedback.Category, nil, nil),
 		toBold(translator.Translate(session.Lang, "message", nil, nil)),
@@ -77,7 +77,7 @@ func BanNotification(session *models.Session, reason string) string {
 		translator.Translate(session.AdminState.UserLang, "bannedBy", map[string]interface{}{
 			"Role": translator.Translate(session.AdminState.UserLang, session.Role.String(), nil, nil),
 		}, nil),
-		formatOptionalString(toBold(translator.Translate(session.AdminState.UserLang, "reason", nil, nil)), reason, "%s: %s\n\n"),
+		formatOptionalString(toBold(translator.Translate(session.AdminState.UserLang, "reason", nil, nil)), escape(reason), "%s: %s\n\n"),
 		translator.Translate(session.AdminState.UserLang, "botAccessDenied", nil, nil))
 }
 
@@ -88,7 +88,7 @@ func Ban(session *models.Session, reason string) string {
 			"ID": session.AdminState.UserID,
 		}, nil),
 		formatOptionalString(toBold(translator.Translate(session.Lang, "reason", nil, nil)),
-			reason, "\n\n%s: %s"))
+			escape(reason), "\n\n%s: %s"))
 }
 
 // UnbanNotification generates an unban notification message for the user being unbanned.
@@ -190,6 +190,7 @@ func RequestBroadcastPin(session *models.Session) string {
 }
 
 // BroadcastPreview generates a preview of the broadcast message.
+// The message is written by an admin and is sent as trusted markup.
 func BroadcastPreview(session *models.Session) string {
 	return fmt.Sprintf("👁️ %s:\n\n%s",
 		toItalic(translator.Translate(session.Lang, "preview", nil, nil)),
@@ -252,7 +253,7 @@ func formatUserEntry(session *models.Session, user models.Session, index int) st
 	return fmt.Sprintf("%s %s\n%s%s%s: %s\n\n",
 		utils.NumberToEmoji(utils.GetItemID(index, session.AdminState.CurrentPage, session.AdminState.PageSize)),
 		toBold(translator.Translate(session.Lang, user.Role.String(), nil, nil)),
-		formatOptionalString("Username", user.TelegramUsername, "%s: @%s\n"),
+		formatOptionalString("Username", escape(user.TelegramUsername), "%s: @%s\n"),
 		formatOptionalNumber("API ID", user.User.ID, -1, "%s: %d\n"),
 		translator.Translate(session.Lang, "access", nil, nil),
 		utils.BoolToEmojiColored(!user.IsBanned))
@@ -265,7 +266,7 @@ func formatUserDetails(session *models.Session, user *models.Session) string {
 		translator.Translate(session.Lang, user.Role.String(), nil, nil),
 		toBold("Telegram ID"),
 		toCode(strconv.Itoa(user.TelegramID)),
-		formatOptionalString(toBold("Username"), user.TelegramUsername, "%s: @%s\n"),
+		formatOptionalString(toBold("Username"), escape(user.TelegramUsername), "%s: @%s\n"),
 		toBold(translator.Translate(session.Lang, "access", nil, nil)),
 		utils.BoolToEmojiColored(!user.IsBanned),
 		toBold(translator.Translate(session.Lang, "language", nil, nil)),
@@ -285,7 +286,7 @@ func formatAPIDetails(session *models.Session, user *models.Session) string {
 		toCode(strconv.Itoa(user.User.ID)),
 		toBold(translator.Translate(session.Lang, "name", nil, nil)),
 		toCode(user.User.Username),
-		formatOptionalString(toBold("Email"), user.User.Email, "%s: %s\n"),
+		formatOptionalString(toBold("Email"), escape(user.User.Email), "%s: %s\n"),
 		toBold(translator.Translate(session.Lang, "created", nil, nil)),
 		user.User.CreatedAt.Format("02.01.2006 15:04"))
 }
@@ -296,7 +297,7 @@ func formatFeedback(session *models.Session, feedback models.Feedback, index int
 		utils.NumberToEmoji(utils.GetItemID(index, session.AdminState.CurrentPage, session.AdminState.PageSize)),
 		toBold(translator.Translate(session.Lang, feedback.Category, nil, nil)),
 		formatOptionalNumber("Telegram ID", feedback.TelegramID, -1, "%s: <code>%d</code>\n"),
-		formatOptionalString("Username", feedback.TelegramUsername, "%s: @%s\n"),
+		formatOptionalString("Username", escape(feedback.TelegramUsername), "%s: @%s\n"),
 		translator.Translate(session.Lang, "created", nil, nil),
 		feedback.CreatedAt.Format("02.01.2006 15:04"),
 		toItalic(fmt.Sprintf("(%d)", feedback.ID)))
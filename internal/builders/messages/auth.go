@@ -9,28 +9,28 @@ import (
 // RegistrationSuccess generates a success message after a user successfully registers.
 func RegistrationSuccess(session *models.Session) string {
 	return "✅ " + translator.Translate(session.Lang, "registrationSuccess", map[string]interface{}{
-		"Username": session.User.Username,
+		"Username": escape(session.User.Username),
 	}, nil)
 }
 
 // Logout generates a confirmation message for logging out.
 func Logout(session *models.Session) string {
 	return "⚠️ " + translator.Translate(session.Lang, "logoutConfirm", map[string]interface{}{
-		"Username": session.User.Username,
+		"Username": escape(session.User.Username),
 	}, nil)
 }
 
 // LogoutFailure generates an error message if the logout process fails.
 func LogoutFailure(session *models.Session) string {
 	return "🚨 " + translator.Translate(session.Lang, "logoutFailure", map[string]interface{}{
-		"Username": session.User.Username,
+		"Username": escape(session.User.Username),
 	}, nil)
 }
 
 // LogoutSuccess generates a success message after the user logs out.
 func LogoutSuccess(session *models.Session) string {
 	return "🚪 " + translator.Translate(session.Lang, "logoutSuccess", map[string]interface{}{
-		"Username": session.User.Username,
+		"Username": escape(session.User.Username),
 	}, nil)
 }
 
@@ -52,21 +52,21 @@ func RequestCollectionDescription(session *models.Session) string {
 // DeleteCollection generates a confirmation message for deleting a collection.
 func DeleteCollection(session *models.Session) string {
 	return "⚠️ " + translator.Translate(session.Lang, "deleteCollectionConfirm", map[string]interface{}{
-		"Collection": session.CollectionDetailState.Collection.Name,
+		"Collection": escape(session.CollectionDetailState.Collection.Name),
 	}, nil)
 }
 
 // DeleteCollectionFailure generates an error message when deleting a collection fails.
 func DeleteCollectionFailure(session *models.Session) string {
 	return "🚨 " + translator.Translate(session.Lang, "deleteCollectionFailure", map[string]interface{}{
-		"Collection": session.CollectionDetailState.Collection.Name,
+		"Collection": escape(session.CollectionDetailState.Collection.Name),
 	}, nil)
 }
 
 // DeleteCollectionSuccess generates a success message after deleting a collection.
 func DeleteCollectionSuccess(session *models.Session) string {
 	return "🗑️ " + translator.Translate(session.Lang, "deleteCollectionSuccess", map[string]interface{}{
-		"Collection": session.CollectionDetailState.Collection.Name,
+		"Collection": escape(session.CollectionDetailState.Collection.Name),
 	}, nil)
 }
 
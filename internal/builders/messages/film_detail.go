@@ -5,6 +5,7 @@ import (
 	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
 	"github.com/k4sper1love/watchlist-bot/internal/handlers/states"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/parsing"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
 	"github.com/k4sper1love/watchlist-bot/pkg/translator"
 	"strings"
@@ -12,15 +13,19 @@ import (
 )
 
 // FilmDetail generates a detailed message about a specific film.
+// The metadata block of the description, such as the director, is shown as a separate section in the user's language.
 func FilmDetail(session *models.Session) string {
 	film := session.FilmDetailState.Film
-	return fmt.Sprintf("%s%s%s\n\n%s%s%s%s%s",
+	description, metadata := parsing.SplitDescription(film.Description)
+	return fmt.Sprintf("%s%s%s\n\n%s%s%s%s%s%s%s",
 		toBold(film.Title),
 		toItalic(formatOptionalNumber("", film.Year, 0, "%s (%d)")),
 		formatOptionalBool("⭐", film.IsFavorite, " %s"),
 		formatFilmDetails(&film),
+		formatOptionalString("", formatSeriesProgress(&film), "%s%s\n\n"),
 		formatOptionalString(toBold(translator.Translate(session.Lang, "description", nil, nil)),
-			toItalic(film.Description), "%s:\n%s\n\n"),
+			toItalic(description), "%s:\n%s\n\n"),
+		formatFilmMetadata(session, metadata),
 		formatOptionalString(toBold(translator.Translate(session.Lang, "comment", nil, nil)),
 			toItalic(film.Comment), "%s:\n%s\n\n"),
 		formatOptionalString(toBold(translator.Translate(session.Lang, "review", nil, nil)),
@@ -30,11 +35,12 @@ func FilmDetail(session *models.Session) string {
 
 // FilmGeneral generates a general message about a film, including its title, year, viewed status, and details.
 func FilmGeneral(session *models.Session, film *apiModels.Film, needViewed bool) string {
-	return fmt.Sprintf("%s%s | %s\n%s%s\n",
+	return fmt.Sprintf("%s%s | %s\n%s%s%s\n",
 		toBold(film.Title),
 		toItalic(fmt.Sprintf(" (%d)", film.Year)),
 		utils.ViewedToEmojiColored(film.IsViewed),
 		formatFilmGeneralDetails(film, needViewed),
+		formatOptionalString("", formatSeriesProgress(film), "%s%s\n"),
 		formatFilmGeneralDescription(session, film))
 }
 
@@ -46,7 +52,7 @@ func formatFilmDetails(film *apiModels.Film) string {
 	details = append(details, fmt.Sprintf("%d", film.ID))
 
 	if film.Genre != "" {
-		details = append(details, film.Genre)
+		details = append(details, escape(film.Genre))
 	}
 	if film.Rating != 0 {
 		details = append(details, fmt.Sprintf("★%.2f", film.Rating))
@@ -61,12 +67,51 @@ func formatFilmDetails(film *apiModels.Film) string {
 	return ""
 }
 
+// formatFilmMetadata formats the fields of the metadata block of a film description as labeled lines.
+func formatFilmMetadata(session *models.Session, fields []parsing.MetadataField) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	lines := make([]string, len(fields))
+	for i, field := range fields {
+		lines[i] = fmt.Sprintf("%s %s: %s",
+			field.Marker,
+			toBold(translator.Translate(session.Lang, field.Key, nil, nil)),
+			toItalic(field.Value))
+	}
+	return strings.Join(lines, "\n") + "\n\n"
+}
+
+// formatSeriesProgress formats the progress of watching a series as a bar with the numbers of episodes
+// and the last watched episode (e.g., "▶️ ▰▰▰▱▱▱▱▱▱▱ 8/28 (S1E8)").
+// Returns an empty string for films that are not series or have no progress to show.
+func formatSeriesProgress(film *apiModels.Film) string {
+	series, ok := parsing.ParseSeries(film.Description)
+	if !ok {
+		return ""
+	}
+
+	episode := series.FormatEpisode()
+	watched, counted := series.Watched()
+	total := series.Total()
+	if !counted || total == 0 {
+		return formatOptionalString("▶️", episode, "%s %s")
+	}
+
+	return fmt.Sprintf("▶️ %s %d/%d%s",
+		utils.ProgressToBar(watched, total),
+		min(watched, total),
+		total,
+		formatOptionalString("", episode, "%s (%s)"))
+}
+
 // formatFilmGeneralDetails formats general details about a film, such as genre, rating, and user rating.
 func formatFilmGeneralDetails(film *apiModels.Film, needViewed bool) string {
 	var details []string
 
 	if film.Genre != "" {
-		details = append(details, fmt.Sprintf("%s", film.Genre))
+		details = append(details, escape(film.Genre))
 	}
 	if film.Rating != 0 {
 		details = append(details, fmt.Sprintf("★%.2f", film.Rating))
@@ -81,7 +126,7 @@ func formatFilmGeneralDetails(film *apiModels.Film, needViewed bool) string {
 	return ""
 }
 
-// formatFilmGeneralDescription formats the description of a film, truncating it if necessary.
+// formatFilmGeneralDescription formats the description of a film without its metadata block, truncating it if necessary.
 // Handles special cases for YouTube videos by extracting the creator's name from the description.
 func formatFilmGeneralDescription(session *models.Session, film *apiModels.Film) string {
 	if film.Description == "" {
@@ -94,12 +139,16 @@ func formatFilmGeneralDescription(session *models.Session, film *apiModels.Film)
 		return fmt.Sprintf("%s\n\n", film.Description)
 	}
 
-	if utf8.RuneCountInString(film.Description) > 230 {
-		film.Description, _ = utils.SplitTextByLength(film.Description, 230)
+	description, _ := parsing.SplitDescription(film.Description)
+	if description == "" {
+		return ""
+	}
+	if utf8.RuneCountInString(description) > 230 {
+		description, _ = utils.SplitTextByLength(description, 230)
 	}
 
 	return fmt.Sprintf("%s:\n%s\n",
 		toBold(translator.Translate(session.Lang, "description", nil, nil)),
-		toItalic(film.Description),
+		toItalic(description),
 	)
 }
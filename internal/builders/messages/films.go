@@ -104,21 +104,21 @@ func RequestFilmTitle(session *models.Session) string {
 // DeleteFilm generates a confirmation message for deleting a film.
 func DeleteFilm(session *models.Session) string {
 	return "⚠️ " + translator.Translate(session.Lang, "deleteFilmConfirm", map[string]interface{}{
-		"Film": session.FilmDetailState.Film.Title,
+		"Film": escape(session.FilmDetailState.Film.Title),
 	}, nil)
 }
 
 // DeleteFilmFailure generates an error message when deleting a film fails.
 func DeleteFilmFailure(session *models.Session) string {
 	return "🚨 " + translator.Translate(session.Lang, "deleteFilmFailure", map[string]interface{}{
-		"Film": session.FilmDetailState.Film.Title,
+		"Film": escape(session.FilmDetailState.Film.Title),
 	}, nil)
 }
 
 // DeleteFilmSuccess generates a success message after deleting a film.
 func DeleteFilmSuccess(session *models.Session) string {
 	return "🗑 " + translator.Translate(session.Lang, "deleteFilmSuccess", map[string]interface{}{
-		"Film": session.FilmDetailState.Film.Title,
+		"Film": escape(session.FilmDetailState.Film.Title),
 	}, nil)
 }
 
@@ -152,6 +152,27 @@ func NewFilmFromURL(session *models.Session) string {
 		toItalic(parsing.GetSupportedServicesInline()))
 }
 
+// NewFilmFromPage generates a message showing the film found on a page of an arbitrary site
+// and explaining that its details are checked step by step.
+func NewFilmFromPage(session *models.Session) string {
+	state := session.FilmDetailState
+	film := &apiModels.Film{
+		Title:       state.Title,
+		Year:        state.Year,
+		Genre:       state.Genre,
+		Description: state.Description,
+		Rating:      state.Rating,
+	}
+
+	return fmt.Sprintf("🔎 %s\n\n%s%s\n%s%s\n%s",
+		toBold(translator.Translate(session.Lang, "filmFoundOnPage", nil, nil)),
+		toBold(film.Title),
+		toItalic(formatOptionalNumber("", film.Year, 0, "%s (%d)")),
+		formatFilmGeneralDetails(film, false),
+		formatFilmGeneralDescription(session, film),
+		translator.Translate(session.Lang, "filmCheckFoundDetails", nil, nil))
+}
+
 // RequestFilmYear generates a message prompting the user to enter a film's release year.
 func RequestFilmYear(session *models.Session) string {
 	return "❓" + translator.Translate(session.Lang, "filmRequestYear", nil, nil)
@@ -202,6 +223,21 @@ func RequestFilmReview(session *models.Session) string {
 	return "❓" + translator.Translate(session.Lang, "filmRequestReview", nil, nil)
 }
 
+// RequestFilmSeasons generates a message prompting the user to enter the numbers of episodes in the seasons of a series.
+func RequestFilmSeasons(session *models.Session) string {
+	return "❓" + translator.Translate(session.Lang, "filmRequestSeasons", nil, nil)
+}
+
+// RequestFilmWatchedEpisode generates a message prompting the user to enter the last watched episode of a series.
+func RequestFilmWatchedEpisode(session *models.Session) string {
+	return "❓" + translator.Translate(session.Lang, "filmRequestWatchedEpisode", nil, nil)
+}
+
+// SeriesFinished generates a message after the last episode of a series has been watched.
+func SeriesFinished(session *models.Session) string {
+	return "🎉 " + toBold(translator.Translate(session.Lang, "seriesFinished", nil, nil))
+}
+
 // CreateFilmFailure generates an error message when creating a film fails.
 func CreateFilmFailure(session *models.Session) string {
 	return "🚨 " + translator.Translate(session.Lang, "createFilmFailure", nil, nil)
@@ -215,7 +251,7 @@ func CreateFilmSuccess(session *models.Session) string {
 // CreateCollectionFilmSuccess generates a success message after adding a film to a collection.
 func CreateCollectionFilmSuccess(session *models.Session, collectionName string) string {
 	return "🎬 " + translator.Translate(session.Lang, "createCollectionFilmSuccess", map[string]interface{}{
-		"Collection": collectionName,
+		"Collection": escape(collectionName),
 	}, nil)
 }
 
@@ -263,8 +299,8 @@ func ChoiceFilm(session *models.Session) string {
 // AddFilmToCollectionSuccess generates a success message after adding a film to a collection.
 func AddFilmToCollectionSuccess(session *models.Session, collectionFilm *apiModels.CollectionFilm) string {
 	return "➕ " + translator.Translate(session.Lang, "filmToCollectionSuccess", map[string]interface{}{
-		"Film":       collectionFilm.Film.Title,
-		"Collection": collectionFilm.Collection.Name,
+		"Film":       escape(collectionFilm.Film.Title),
+		"Collection": escape(collectionFilm.Collection.Name),
 	}, nil)
 }
 
@@ -68,8 +68,42 @@ func InvalidFilterRange(session *models.Session, config utils.FilterRangeConfig)
 		toCode("1990-2023"),
 		translator.Translate(session.Lang, "examplePartialRange", nil, nil),
 		toCode("5-"), toCode("-10"),
-		toItalic(translator.Translate(session.Lang, "rangeLimits", map[string]interface{}{
+		toItalicMarkup(translator.Translate(session.Lang, "rangeLimits", map[string]interface{}{
 			"Min": fmt.Sprintf("%.f", config.MinValue),
 			"Max": fmt.Sprintf("%.f", config.MaxValue),
 		}, nil)))
 }
+
+// SearchFilters generates a message prompting the user to refine the search for new films on Kinopoisk.
+func SearchFilters(session *models.Session) string {
+	return fmt.Sprintf("⚙️ %s\n\n%s",
+		toBold(translator.Translate(session.Lang, "searchFiltersTitle", nil, nil)),
+		translator.Translate(session.Lang, "searchFiltersHint", nil, nil))
+}
+
+// RequestSearchFilter generates a message prompting the user to enter the value of a specific search filter,
+// showing its current value if it is active.
+func RequestSearchFilter(session *models.Session, field string) string {
+	var instruction string
+	switch field {
+	case "year":
+		instruction = fmt.Sprintf("↕️ %s\n\n%s",
+			translator.Translate(session.Lang, "filterInstructionRange", nil, nil),
+			translator.Translate(session.Lang, "filterInstructionPartialRange", nil, nil))
+	case "minRating":
+		instruction = "⭐ " + translator.Translate(session.Lang, "requestSearchRating", nil, nil)
+	case "genre":
+		instruction = "🎭 " + translator.Translate(session.Lang, "requestSearchGenre", nil, nil)
+	case "country":
+		instruction = "🌍 " + translator.Translate(session.Lang, "requestSearchCountry", nil, nil)
+	case "filmType":
+		instruction = "🎞 " + translator.Translate(session.Lang, "requestSearchType", nil, nil)
+	}
+
+	value := session.FilmsState.SearchFilters.String(field)
+	if field == "filmType" && value != "" {
+		value = translator.Translate(session.Lang, value, nil, nil)
+	}
+	return instruction + formatOptionalString(toBold(translator.Translate(session.Lang, "currentValue", nil, nil)),
+		toItalic(value), "\n\n%s: %s")
+}
@@ -3,6 +3,7 @@ package messages
 import (
 	"fmt"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/parsing"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
 	"github.com/k4sper1love/watchlist-bot/pkg/security"
 	"github.com/k4sper1love/watchlist-bot/pkg/translator"
@@ -23,8 +24,12 @@ func Start(app models.App, session *models.Session) string {
 }
 
 // Help generates a help message for the user.
+// The list of supported services is taken from the registered film sources.
 func Help(session *models.Session) string {
-	return translator.Translate(session.Lang, "helpMessage", nil, nil)
+	return fmt.Sprintf("%s\n\n%s:\n%s",
+		translator.Translate(session.Lang, "helpMessage", nil, nil),
+		translator.Translate(session.Lang, "supportedServices", nil, nil),
+		toItalic(parsing.GetSupportedServicesInline()))
 }
 
 // Menu generates a message for the main menu.
@@ -61,13 +66,32 @@ func RequestKinopoiskToken(session *models.Session) string {
 	return fmt.Sprintf("⚠️ %s\n\n%s%s",
 		translator.Translate(session.Lang, "tokenRequestInfo", nil, nil),
 		formatOptionalString(translator.Translate(session.Lang, "currentToken", nil, nil),
-			toCode(token), "%s: %s\n\n"),
+			toCode(maskToken(token)), "%s: %s\n\n"),
 		toBold(translator.Translate(session.Lang, "tokenRequest", nil, nil)))
 }
 
+// KinopoiskTokenConfirm generates a message showing a masked preview of the received Kinopoisk API token.
+// Offers to test the token before saving it.
+func KinopoiskTokenConfirm(session *models.Session) string {
+	token, _ := security.Decrypt(session.PendingKinopoiskToken)
+	return fmt.Sprintf("🔐 %s: %s\n\n%s",
+		translator.Translate(session.Lang, "tokenReceived", nil, nil),
+		toCode(maskToken(token)),
+		toBold(translator.Translate(session.Lang, "tokenTestOffer", nil, nil)))
+}
+
+// KinopoiskTokenValid generates a message confirming that the Kinopoisk API token passed the test request.
+func KinopoiskTokenValid(session *models.Session) string {
+	return "🟢 " + translator.Translate(session.Lang, "tokenValid", nil, nil)
+}
+
 // KinopoiskTokenSuccess generates a success message after the user sets a valid Kinopoisk API token.
+// Includes a masked preview of the saved token.
 func KinopoiskTokenSuccess(session *models.Session) string {
-	return "✅ " + translator.Translate(session.Lang, "tokenSuccess", nil, nil)
+	token, _ := security.Decrypt(session.KinopoiskAPIToken)
+	return fmt.Sprintf("✅ %s: %s",
+		translator.Translate(session.Lang, "tokenSuccess", nil, nil),
+		toCode(maskToken(token)))
 }
 
 // UnknownCommand generates a message for unrecognized commands.
@@ -116,6 +140,42 @@ func KinopoiskFailureCode(session *models.Session, code int) string {
 	return "🚨 " + translator.Translate(session.Lang, "tokenCodeError."+strconv.Itoa(code), nil, nil)
 }
 
+// ServiceFailureCode generates a message indicating a failure of an external service.
+// Includes the specific error code.
+func ServiceFailureCode(session *models.Session, code int) string {
+	return "🚨 " + translator.Translate(session.Lang, "serviceCodeError."+strconv.Itoa(code), nil, nil)
+}
+
+// KinopoiskTokenCheckFailure generates a message indicating that the Kinopoisk API token could not be tested.
+func KinopoiskTokenCheckFailure(session *models.Session) string {
+	return "🚨 " + translator.Translate(session.Lang, "tokenCheckFailure", nil, nil)
+}
+
+// ServiceDegraded generates a banner shown above cached data while the Watchlist service is unavailable.
+func ServiceDegraded(session *models.Session) string {
+	return "⚠️ " + toItalic(translator.Translate(session.Lang, "serviceDegraded", nil, nil)) + "\n\n"
+}
+
+// ReadOnlyMode generates a message refusing a change while the Watchlist service is unavailable.
+func ReadOnlyMode(session *models.Session) string {
+	return "🔒 " + translator.Translate(session.Lang, "readOnlyMode", nil, nil)
+}
+
+// ServiceUnavailable generates a message indicating that the Watchlist service is unavailable and no cached data can be shown.
+func ServiceUnavailable(session *models.Session) string {
+	return "🚨 " + translator.Translate(session.Lang, "serviceUnavailable", nil, nil)
+}
+
+// ServiceDown generates a notification for admins that the Watchlist API became unavailable.
+func ServiceDown(session *models.Session) string {
+	return "🔴 " + translator.Translate(session.Lang, "serviceDown", nil, nil)
+}
+
+// ServiceRecovered generates a notification for admins that the Watchlist API is available again.
+func ServiceRecovered(session *models.Session) string {
+	return "🟢 " + translator.Translate(session.Lang, "serviceRecovered", nil, nil)
+}
+
 // SomeError generates a generic error message.
 func SomeError(session *models.Session) string {
 	return "🚨 " + translator.Translate(session.Lang, "someError", nil, nil)
@@ -3,33 +3,52 @@ package messages
 import (
 	"fmt"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/utils"
 	"github.com/k4sper1love/watchlist-bot/pkg/translator"
+	"html"
 )
 
-// wrapText wraps the given text with a specified format if the text is not empty.
+// escape escapes HTML special characters so that user-supplied or scraped text is displayed literally.
+// Every value that does not come from the bot itself must pass through escape or one of the to* helpers.
+func escape(text string) string {
+	return html.EscapeString(text)
+}
+
+// wrapText escapes the given text and wraps it with a specified format if the text is not empty.
 func wrapText(text, format string) string {
-	if text == "" {
+	return wrapMarkup(escape(text), format)
+}
+
+// wrapMarkup wraps trusted markup with a specified format without escaping it.
+// Use it only for markup produced by the bot, such as translations containing HTML tags.
+func wrapMarkup(markup, format string) string {
+	if markup == "" {
 		return ""
 	}
-	return fmt.Sprintf(format, text)
+	return fmt.Sprintf(format, markup)
 }
 
-// toBold formats the given text as bold using HTML tags.
+// toBold escapes the given text and formats it as bold using HTML tags.
 func toBold(text string) string {
 	return wrapText(text, "<b>%s</b>")
 }
 
-// toCode formats the given text as inline code using HTML tags.
+// toCode escapes the given text and formats it as inline code using HTML tags.
 func toCode(text string) string {
 	return wrapText(text, "<code>%s</code>")
 }
 
-// toItalic formats the given text as italic using HTML tags.
+// toItalic escapes the given text and formats it as italic using HTML tags.
 func toItalic(text string) string {
 	return wrapText(text, "<i>%s</i>")
 }
 
-// toPre formats the given text as preformatted text using HTML tags.
+// toItalicMarkup formats trusted markup as italic using HTML tags without escaping it.
+func toItalicMarkup(markup string) string {
+	return wrapMarkup(markup, "<i>%s</i>")
+}
+
+// toPre escapes the given text and formats it as preformatted text using HTML tags.
 func toPre(text string) string {
 	return wrapText(text, "<pre>%s</pre>")
 }
@@ -74,3 +93,11 @@ func nonEmpty(value, fallback string) string {
 	}
 	return value
 }
+
+// maskToken returns a masked preview of the token, or an empty string if there is no token.
+func maskToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	return utils.MaskSecret(token)
+}
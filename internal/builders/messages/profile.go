@@ -47,34 +47,34 @@ func RequestProfileEmail(session *models.Session) string {
 // UpdateProfileFailure generates an error message when updating the user's profile fails.
 func UpdateProfileFailure(session *models.Session) string {
 	return "🚨 " + translator.Translate(session.Lang, "updateProfileFailure", map[string]interface{}{
-		"Username": session.User.Username,
+		"Username": escape(session.User.Username),
 	}, nil)
 }
 
 // UpdateProfileSuccess generates a success message after updating the user's profile.
 func UpdateProfileSuccess(session *models.Session) string {
 	return "✏️ " + translator.Translate(session.Lang, "updateProfileSuccess", map[string]interface{}{
-		"Username": session.User.Username,
+		"Username": escape(session.User.Username),
 	}, nil)
 }
 
 // DeleteProfile generates a confirmation message for deleting the user's profile.
 func DeleteProfile(session *models.Session) string {
 	return "⚠️ " + translator.Translate(session.Lang, "deleteProfileConfirm", map[string]interface{}{
-		"Username": session.User.Username,
+		"Username": escape(session.User.Username),
 	}, nil)
 }
 
 // DeleteProfileFailure generates an error message when deleting the user's profile fails.
 func DeleteProfileFailure(session *models.Session) string {
 	return "🚨 " + translator.Translate(session.Lang, "deleteProfileFailure", map[string]interface{}{
-		"Username": session.User.Username,
+		"Username": escape(session.User.Username),
 	}, nil)
 }
 
 // DeleteProfileSuccess generates a success message after deleting the user's profile.
 func DeleteProfileSuccess(session *models.Session) string {
 	return "🗑️ " + translator.Translate(session.Lang, "deleteProfileSuccess", map[string]interface{}{
-		"Username": session.User.Username,
+		"Username": escape(session.User.Username),
 	}, nil)
 }
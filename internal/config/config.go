@@ -10,6 +10,7 @@ import (
 	"log/slog"
 	"os"
 	"strconv"
+	"time"
 )
 
 // Init initializes the application configuration by reading environment variables.
@@ -35,11 +36,60 @@ func Init() (*models.App, error) {
 		RootID:          rootID,
 		YoutubeAPIToken: getEnvOrDefault("YOUTUBE_API_TOKEN", ""),
 		IMDBAPIToken:    getEnvOrDefault("IMDB_API_TOKEN", ""),
+		TMDBAPIToken:    getEnvOrDefault("TMDB_API_TOKEN", ""),
+
+		UpdatesMode:       getEnvOrDefault("UPDATES_MODE", "polling"),
+		WebhookURL:        os.Getenv("WEBHOOK_URL"),
+		WebhookListenAddr: os.Getenv("WEBHOOK_LISTEN_ADDR"),
+		WebhookSecret:     os.Getenv("WEBHOOK_SECRET"),
+		WebhookCertFile:   os.Getenv("WEBHOOK_CERT_FILE"),
+		WebhookKeyFile:    os.Getenv("WEBHOOK_KEY_FILE"),
+
+		MaxConcurrentUpdates: getIntEnvOrDefault("MAX_CONCURRENT_UPDATES", 64),
+		UserQueueSize:        getIntEnvOrDefault("USER_QUEUE_SIZE", 16),
+		WorkerIdleTimeout:    getDurationEnvOrDefault("WORKER_IDLE_TIMEOUT", time.Minute),
+		ShutdownTimeout:      getDurationEnvOrDefault("SHUTDOWN_TIMEOUT", 30*time.Second),
+
+		WatchlistTimeout:   getDurationEnvOrDefault("WATCHLIST_API_TIMEOUT", 10*time.Second),
+		KinopoiskTimeout:   getDurationEnvOrDefault("KINOPOISK_TIMEOUT", 10*time.Second),
+		ExternalTimeout:    getDurationEnvOrDefault("EXTERNAL_TIMEOUT", 15*time.Second),
+		ImageTimeout:       getDurationEnvOrDefault("IMAGE_TIMEOUT", 30*time.Second),
+		HTTPMaxAttempts:    getIntEnvOrDefault("HTTP_MAX_ATTEMPTS", 3),
+		HTTPRetryBaseDelay: getDurationEnvOrDefault("HTTP_RETRY_BASE_DELAY", 300*time.Millisecond),
+
+		ListCacheTTL:              getDurationEnvOrDefault("LIST_CACHE_TTL", 30*time.Second),
+		WatchlistBreakerThreshold: getIntEnvOrDefault("WATCHLIST_BREAKER_THRESHOLD", 5),
+		WatchlistBreakerTimeout:   getDurationEnvOrDefault("WATCHLIST_BREAKER_TIMEOUT", 30*time.Second),
+	}
+
+	if err = validateUpdatesMode(config); err != nil {
+		return nil, err
 	}
 
 	return &models.App{Config: config}, nil
 }
 
+// validateUpdatesMode checks that the update delivery mode is supported and fully configured.
+func validateUpdatesMode(config *models.Config) error {
+	switch config.UpdatesMode {
+	case "polling":
+		return nil
+	case "webhook":
+		if config.WebhookURL == "" || config.WebhookListenAddr == "" || config.WebhookSecret == "" {
+			slog.Error("webhook mode requires WEBHOOK_URL, WEBHOOK_LISTEN_ADDR and WEBHOOK_SECRET")
+			return fmt.Errorf("webhook mode is not fully configured")
+		}
+		if (config.WebhookCertFile == "") != (config.WebhookKeyFile == "") {
+			slog.Error("WEBHOOK_CERT_FILE and WEBHOOK_KEY_FILE must be set together")
+			return fmt.Errorf("incomplete webhook TLS configuration")
+		}
+		return nil
+	default:
+		slog.Error("unsupported updates mode", slog.String("mode", config.UpdatesMode))
+		return fmt.Errorf("unsupported UPDATES_MODE: %s", config.UpdatesMode)
+	}
+}
+
 // buildDatabaseURL constructs the PostgreSQL database connection URL from environment variables.
 // It uses default values if any required environment variable is missing.
 func buildDatabaseURL() string {
@@ -78,3 +128,23 @@ func getEnvOrDefault(key, defaultValue string) string {
 	}
 	return value
 }
+
+// getIntEnvOrDefault retrieves a positive integer environment variable or returns a default value if it is unset or invalid.
+func getIntEnvOrDefault(key string, defaultValue int) int {
+	value, err := strconv.Atoi(getEnvOrDefault(key, strconv.Itoa(defaultValue)))
+	if err != nil || value <= 0 {
+		slog.Warn("invalid environment variable, using default", slog.String("key", key), slog.Int("default", defaultValue))
+		return defaultValue
+	}
+	return value
+}
+
+// getDurationEnvOrDefault retrieves a positive duration environment variable (e.g., "30s") or returns a default value.
+func getDurationEnvOrDefault(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(getEnvOrDefault(key, defaultValue.String()))
+	if err != nil || value <= 0 {
+		slog.Warn("invalid environment variable, using default", slog.String("key", key), slog.String("default", defaultValue.String()))
+		return defaultValue
+	}
+	return value
+}
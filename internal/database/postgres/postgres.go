@@ -12,8 +12,14 @@ var db *gorm.DB // Global database connection instance
 // ConnectDatabase establishes a connection to the PostgreSQL database using the provided database URL.
 // It also performs automatic migration to ensure the database schema is up-to-date.
 func ConnectDatabase(databaseURL string) error {
+	return OpenDatabase(postgres.Open(databaseURL))
+}
+
+// OpenDatabase opens the global database connection with the given dialector and migrates the schema.
+// It allows other databases, such as an in-memory SQLite database in tests, to back the package.
+func OpenDatabase(dialector gorm.Dialector) error {
 	var err error
-	db, err = gorm.Open(postgres.Open(databaseURL), &gorm.Config{})
+	db, err = gorm.Open(dialector, &gorm.Config{})
 	if err != nil {
 		slog.Error("failed to open database connection", slog.Any("error", err))
 		return err
@@ -42,6 +48,16 @@ func autoMigrate() error {
 	)
 }
 
+// CloseDatabase closes the underlying connection pool of the global database instance.
+func CloseDatabase() error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		slog.Error("failed to get database connection pool", slog.Any("error", err))
+		return err
+	}
+	return sqlDB.Close()
+}
+
 // GetDatabase returns the global database connection instance.
 // This function is used to access the database throughout the application.
 func GetDatabase() *gorm.DB {
@@ -139,3 +139,22 @@ func initializeSessionDefaults(app models.App, session *models.Session) {
 func SaveSessionWithDependencies(session *models.Session) {
 	GetDatabase().Session(&gorm.Session{FullSaveAssociations: true}).Save(session)
 }
+
+// SaveSessionTokens saves the access and refresh tokens of a stored session without touching its other data.
+// Sessions that are not stored yet are skipped, as they are saved with all their data at the end of the update.
+func SaveSessionTokens(session *models.Session) {
+	if session.ID == 0 {
+		return
+	}
+
+	if err := GetDatabase().Model(session).Updates(map[string]interface{}{
+		"access_token":  session.AccessToken,
+		"refresh_token": session.RefreshToken,
+	}).Error; err != nil {
+		slog.Warn(
+			"failed to save session tokens",
+			slog.Any("error", err),
+			slog.Int("telegram_id", session.TelegramID),
+		)
+	}
+}
@@ -0,0 +1,187 @@
+package fakeapi
+
+import (
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-api/pkg/tokens"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// verify wraps a Telegram auth handler, requiring a verification token signed with the API secret.
+// The handler receives the Telegram ID from the token.
+func (a *API) verify(next func(w http.ResponseWriter, r *http.Request, telegramID int)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		telegramID, err := parseToken(r.Header.Get("Verification"), a.secret)
+		if err != nil {
+			errorResponse(w, http.StatusForbidden, "invalid or missing verification token")
+			return
+		}
+		next(w, r, telegramID)
+	}
+}
+
+// authenticate wraps a handler, requiring an access token issued by the fake API for an existing user.
+// The handler receives the ID of the authenticated user.
+func (a *API) authenticate(next func(w http.ResponseWriter, r *http.Request, userID int)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, err := parseToken(parseTokenFromHeader(r), a.accessTokenSecret())
+		if err != nil {
+			invalidAuthTokenResponse(w)
+			return
+		}
+
+		a.store.mu.Lock()
+		_, ok := a.store.users[userID]
+		a.store.mu.Unlock()
+
+		if !ok {
+			invalidAuthTokenResponse(w)
+			return
+		}
+		next(w, r, userID)
+	}
+}
+
+// registerByTelegram creates a user for the Telegram ID and issues tokens.
+func (a *API) registerByTelegram(w http.ResponseWriter, _ *http.Request, telegramID int) {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	if _, ok := a.store.telegramUsers[telegramID]; ok {
+		errorResponse(w, http.StatusConflict, "resource already exists")
+		return
+	}
+
+	user := a.store.addUser(telegramID, fmt.Sprintf("user_%d", telegramID))
+	a.writeAuthResponse(w, http.StatusCreated, user)
+}
+
+// loginByTelegram issues tokens for the user registered with the Telegram ID.
+func (a *API) loginByTelegram(w http.ResponseWriter, _ *http.Request, telegramID int) {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	userID, ok := a.store.telegramUsers[telegramID]
+	if !ok {
+		notFoundResponse(w)
+		return
+	}
+
+	a.writeAuthResponse(w, http.StatusOK, a.store.users[userID])
+}
+
+// refreshAccessToken issues a new access token for an active refresh token.
+func (a *API) refreshAccessToken(w http.ResponseWriter, r *http.Request) {
+	userID, ok := a.activeRefreshToken(r)
+	if !ok {
+		invalidAuthTokenResponse(w)
+		return
+	}
+
+	accessToken, err := tokens.GenerateToken(a.accessTokenSecret(), userID, a.accessTokenTTL)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, envelope{"access_token": accessToken})
+}
+
+// logout revokes an active refresh token.
+func (a *API) logout(w http.ResponseWriter, r *http.Request) {
+	if _, ok := a.activeRefreshToken(r); !ok {
+		invalidAuthTokenResponse(w)
+		return
+	}
+
+	a.store.mu.Lock()
+	delete(a.store.refreshTokens, parseTokenFromHeader(r))
+	a.store.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, envelope{"message": "token revoked"})
+}
+
+// checkToken confirms that the access token is valid.
+func (a *API) checkToken(w http.ResponseWriter, _ *http.Request, _ int) {
+	writeJSON(w, http.StatusOK, envelope{"message": "token is valid"})
+}
+
+// writeAuthResponse issues a token pair for the user and writes it with the user.
+// The caller must hold the store lock.
+func (a *API) writeAuthResponse(w http.ResponseWriter, status int, user *apiModels.User) {
+	accessToken, err := tokens.GenerateToken(a.accessTokenSecret(), user.ID, a.accessTokenTTL)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	refreshToken, err := tokens.GenerateToken(a.refreshSecret, user.ID, a.refreshTokenTTL)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	a.store.refreshTokens[refreshToken] = user.ID
+
+	response := apiModels.AuthResponse{User: user, AccessToken: accessToken, RefreshToken: refreshToken}
+	writeJSON(w, status, envelope{"user": response})
+}
+
+// activeRefreshToken returns the user ID of the refresh token in the request if it is valid and not revoked.
+func (a *API) activeRefreshToken(r *http.Request) (int, bool) {
+	token := parseTokenFromHeader(r)
+	if _, err := parseToken(token, a.refreshSecret); err != nil {
+		return 0, false
+	}
+
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	userID, ok := a.store.refreshTokens[token]
+	return userID, ok
+}
+
+// ExpireAccessTokens makes every access token issued so far invalid while keeping refresh tokens active,
+// so that the next authorized request of the bot fails with 401 and has to refresh its token.
+func (a *API) ExpireAccessTokens() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.accessSecret = randomString(32)
+}
+
+// accessTokenSecret returns the secret currently used to sign access tokens.
+func (a *API) accessTokenSecret() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.accessSecret
+}
+
+// parseTokenFromHeader extracts the token from the Authorization header, with or without the Bearer prefix.
+func parseTokenFromHeader(r *http.Request) string {
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+// parseToken validates a token signed with the secret and returns the ID from its subject.
+func parseToken(tokenString, secret string) (int, error) {
+	claims := &apiModels.JWTClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+	if err != nil || !token.Valid {
+		return 0, fmt.Errorf("invalid token")
+	}
+
+	return strconv.Atoi(claims.Sub)
+}
+
+// invalidAuthTokenResponse reports a missing, invalid or revoked token.
+func invalidAuthTokenResponse(w http.ResponseWriter) {
+	errorResponse(w, http.StatusUnauthorized, "invalid or missing authentication token")
+}
@@ -0,0 +1,179 @@
+package fakeapi
+
+import (
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"net/http"
+)
+
+// getCollectionFilms returns a filtered, sorted page of the films in a collection owned by the user.
+func (a *API) getCollectionFilms(w http.ResponseWriter, r *http.Request, userID int) {
+	collectionID, err := parseIDParam(r, "collectionID")
+	if err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+
+	query, errs := parseFilmsQuery(r.URL.Query())
+	if errs != nil {
+		failedValidationResponse(w, errs)
+		return
+	}
+
+	a.store.mu.Lock()
+	if _, ok := a.store.ownedCollection(userID, collectionID); !ok {
+		a.store.mu.Unlock()
+		forbiddenResponse(w)
+		return
+	}
+
+	var films []apiModels.Film
+	for filmID := range a.store.collectionFilms[collectionID] {
+		if film := a.store.films[filmID]; query.matchFilm(film) {
+			films = append(films, *film)
+		}
+	}
+	collection := a.store.collection(collectionID)
+	a.store.mu.Unlock()
+
+	sortFilms(films, query.sort)
+	page, metadata := paginate(films, query.pagination)
+
+	collectionFilms := apiModels.CollectionFilms{Collection: collection, Films: page}
+	writeJSON(w, http.StatusOK, envelope{"collection_films": collectionFilms, "metadata": metadata})
+}
+
+// addNewCollectionFilm creates a film owned by the user and adds it to a collection.
+func (a *API) addNewCollectionFilm(w http.ResponseWriter, r *http.Request, userID int) {
+	collectionID, err := parseIDParam(r, "collectionID")
+	if err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+
+	var film apiModels.Film
+	if err = parseRequestBody(r, &film); err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+	film.UserID = userID
+	setDefaultImage(r, &film)
+
+	if errs := validateFilm(&film); errs != nil {
+		failedValidationResponse(w, errs)
+		return
+	}
+
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	if _, ok := a.store.ownedCollection(userID, collectionID); !ok {
+		forbiddenResponse(w)
+		return
+	}
+
+	created := a.store.addFilm(film)
+	entry, _ := a.store.addCollectionFilm(collectionID, created.ID)
+
+	writeJSON(w, http.StatusCreated, envelope{"collection_film": a.collectionFilm(collectionID, created.ID, entry)})
+}
+
+// addCollectionFilm adds an existing film owned by the user to a collection.
+func (a *API) addCollectionFilm(w http.ResponseWriter, r *http.Request, userID int) {
+	collectionID, filmID, err := parseCollectionFilmIDs(r)
+	if err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	_, ownsCollection := a.store.ownedCollection(userID, collectionID)
+	_, ownsFilm := a.store.ownedFilm(userID, filmID)
+	if !ownsCollection || !ownsFilm {
+		forbiddenResponse(w)
+		return
+	}
+
+	entry, added := a.store.addCollectionFilm(collectionID, filmID)
+	if !added {
+		errorResponse(w, http.StatusConflict, "resource already exists")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, envelope{"collection_film": a.collectionFilm(collectionID, filmID, entry)})
+}
+
+// getCollectionFilm returns a film of a collection owned by the user.
+func (a *API) getCollectionFilm(w http.ResponseWriter, r *http.Request, userID int) {
+	collectionID, filmID, err := parseCollectionFilmIDs(r)
+	if err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	if _, ok := a.store.ownedCollection(userID, collectionID); !ok {
+		forbiddenResponse(w)
+		return
+	}
+
+	entry, ok := a.store.collectionFilms[collectionID][filmID]
+	if !ok {
+		notFoundResponse(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, envelope{"collection_film": a.collectionFilm(collectionID, filmID, entry)})
+}
+
+// deleteCollectionFilm removes a film from a collection owned by the user, keeping the film.
+func (a *API) deleteCollectionFilm(w http.ResponseWriter, r *http.Request, userID int) {
+	collectionID, filmID, err := parseCollectionFilmIDs(r)
+	if err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	if _, ok := a.store.ownedCollection(userID, collectionID); !ok {
+		forbiddenResponse(w)
+		return
+	}
+
+	if _, ok := a.store.collectionFilms[collectionID][filmID]; !ok {
+		notFoundResponse(w)
+		return
+	}
+	delete(a.store.collectionFilms[collectionID], filmID)
+
+	writeJSON(w, http.StatusOK, envelope{"message": "collection_film deleted"})
+}
+
+// parseCollectionFilmIDs extracts the collection and film IDs from the path.
+func parseCollectionFilmIDs(r *http.Request) (int, int, error) {
+	collectionID, err := parseIDParam(r, "collectionID")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	filmID, err := parseIDParam(r, "filmID")
+	if err != nil {
+		return 0, 0, err
+	}
+	return collectionID, filmID, nil
+}
+
+// collectionFilm builds the collection film response. The caller must hold the store lock.
+func (a *API) collectionFilm(collectionID, filmID int, entry collectionFilmEntry) apiModels.CollectionFilm {
+	return apiModels.CollectionFilm{
+		Collection: a.store.collection(collectionID),
+		Film:       *a.store.films[filmID],
+		AddedAt:    entry.addedAt,
+		UpdatedAt:  entry.updatedAt,
+	}
+}
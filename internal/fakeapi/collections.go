@@ -0,0 +1,146 @@
+package fakeapi
+
+import (
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"net/http"
+	"time"
+)
+
+// getCollections returns a filtered, sorted page of the user's collections.
+func (a *API) getCollections(w http.ResponseWriter, r *http.Request, userID int) {
+	query, errs := parseCollectionsQuery(r.URL.Query())
+	if errs != nil {
+		failedValidationResponse(w, errs)
+		return
+	}
+
+	a.store.mu.Lock()
+	var collections []apiModels.Collection
+	for id, collection := range a.store.collections {
+		if collection.UserID != userID || !containsFold(collection.Name, query.name) {
+			continue
+		}
+
+		films := a.store.collectionFilms[id]
+		if _, ok := films[query.film]; query.film != -1 && !ok {
+			continue
+		}
+		if _, ok := films[query.excludeFilm]; ok {
+			continue
+		}
+		collections = append(collections, a.store.collection(id))
+	}
+	a.store.mu.Unlock()
+
+	sortCollections(collections, query.sort)
+	page, metadata := paginate(collections, query.pagination)
+
+	writeJSON(w, http.StatusOK, envelope{"collections": page, "metadata": metadata})
+}
+
+// addCollection creates a collection owned by the user.
+func (a *API) addCollection(w http.ResponseWriter, r *http.Request, userID int) {
+	var collection apiModels.Collection
+	if err := parseRequestBody(r, &collection); err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+	collection.UserID = userID
+
+	if errs := validateCollection(&collection); errs != nil {
+		failedValidationResponse(w, errs)
+		return
+	}
+
+	a.store.mu.Lock()
+	created := a.store.collection(a.store.addCollection(collection).ID)
+	a.store.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, envelope{"collection": created})
+}
+
+// getCollection returns a collection owned by the user.
+func (a *API) getCollection(w http.ResponseWriter, r *http.Request, userID int) {
+	collectionID, err := parseIDParam(r, "collectionID")
+	if err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	if _, ok := a.store.ownedCollection(userID, collectionID); !ok {
+		forbiddenResponse(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, envelope{"collection": a.store.collection(collectionID)})
+}
+
+// updateCollection applies the fields from the request body to a collection owned by the user.
+func (a *API) updateCollection(w http.ResponseWriter, r *http.Request, userID int) {
+	collectionID, err := parseIDParam(r, "collectionID")
+	if err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	collection, ok := a.store.ownedCollection(userID, collectionID)
+	if !ok {
+		forbiddenResponse(w)
+		return
+	}
+
+	updated := *collection
+	if err = parseRequestBody(r, &updated); err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+	updated.ID, updated.UserID, updated.CreatedAt = collection.ID, collection.UserID, collection.CreatedAt
+	updated.UpdatedAt = time.Now()
+
+	if errs := validateCollection(&updated); errs != nil {
+		failedValidationResponse(w, errs)
+		return
+	}
+
+	*collection = updated
+	writeJSON(w, http.StatusOK, envelope{"collection": a.store.collection(collectionID)})
+}
+
+// deleteCollection deletes a collection owned by the user, keeping its films.
+func (a *API) deleteCollection(w http.ResponseWriter, r *http.Request, userID int) {
+	collectionID, err := parseIDParam(r, "collectionID")
+	if err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	if _, ok := a.store.ownedCollection(userID, collectionID); !ok {
+		forbiddenResponse(w)
+		return
+	}
+	a.store.deleteCollection(collectionID)
+
+	writeJSON(w, http.StatusOK, envelope{"message": "collection deleted"})
+}
+
+// validateCollection checks the collection against the constraints of the real API.
+func validateCollection(collection *apiModels.Collection) map[string]string {
+	errs := make(map[string]string)
+
+	checkLength(errs, "name", collection.Name, 3, 100)
+	checkLength(errs, "description", collection.Description, 0, 500)
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+// Package fakeapi provides an in-memory implementation of the Watchlist API for offline and end-to-end testing.
+//
+// The fake serves the endpoints used by the bot: Telegram authentication, token refresh and logout,
+// the current user, films, collections, collection films, image uploads and the health check.
+// Data is kept in memory and is lost once the API is discarded.
+//
+// Filtering, sorting and pagination follow the real API: titles and names are matched case-insensitively,
+// ratings and years accept an exact value or a "min-max" range, the default sort is "-is_favorite"
+// with the ID as a tie-breaker, and pages default to 5 records.
+//
+// Telegram login and registration require a verification token signed with the configured secret,
+// exactly as the bot generates it. All other endpoints, except the health check and image routes,
+// require an access token issued by the fake itself.
+//
+// Usage in tests:
+//
+//	server := fakeapi.NewServer(fakeapi.Options{Secret: "secret"})
+//	defer server.Close()
+//
+//	client := watchlist.NewWatchlistClient(watchlist.Options{BaseURL: server.URL, APISecret: "secret"})
+//
+// The same API can be served standalone with the cmd/fakeapi binary.
+package fakeapi
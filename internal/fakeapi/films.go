@@ -0,0 +1,202 @@
+package fakeapi
+
+import (
+	"fmt"
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"net/http"
+	"net/url"
+	"time"
+	"unicode/utf8"
+)
+
+// getFilms returns a filtered, sorted page of the user's films.
+func (a *API) getFilms(w http.ResponseWriter, r *http.Request, userID int) {
+	query, errs := parseFilmsQuery(r.URL.Query())
+	if errs != nil {
+		failedValidationResponse(w, errs)
+		return
+	}
+
+	a.store.mu.Lock()
+	var films []apiModels.Film
+	for _, film := range a.store.films {
+		if film.UserID != userID || !query.matchFilm(film) {
+			continue
+		}
+		if _, ok := a.store.collectionFilms[query.excludeCollection][film.ID]; ok {
+			continue
+		}
+		films = append(films, *film)
+	}
+	a.store.mu.Unlock()
+
+	sortFilms(films, query.sort)
+	page, metadata := paginate(films, query.pagination)
+
+	writeJSON(w, http.StatusOK, envelope{"films": page, "metadata": metadata})
+}
+
+// Films returns the films of the user with the Telegram ID ordered by ID, so tests can check what the bot stored.
+func (a *API) Films(telegramID int) []apiModels.Film {
+	a.store.mu.Lock()
+	var films []apiModels.Film
+	for _, film := range a.store.films {
+		if film.UserID == a.store.telegramUsers[telegramID] {
+			films = append(films, *film)
+		}
+	}
+	a.store.mu.Unlock()
+
+	sortFilms(films, "id")
+	return films
+}
+
+// addFilm creates a film owned by the user.
+func (a *API) addFilm(w http.ResponseWriter, r *http.Request, userID int) {
+	var film apiModels.Film
+	if err := parseRequestBody(r, &film); err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+	film.UserID = userID
+	setDefaultImage(r, &film)
+
+	if errs := validateFilm(&film); errs != nil {
+		failedValidationResponse(w, errs)
+		return
+	}
+
+	a.store.mu.Lock()
+	created := *a.store.addFilm(film)
+	a.store.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, envelope{"film": created})
+}
+
+// getFilm returns a film owned by the user.
+func (a *API) getFilm(w http.ResponseWriter, r *http.Request, userID int) {
+	filmID, err := parseIDParam(r, "filmID")
+	if err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	film, ok := a.store.ownedFilm(userID, filmID)
+	if !ok {
+		forbiddenResponse(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, envelope{"film": film})
+}
+
+// updateFilm applies the fields from the request body to a film owned by the user.
+func (a *API) updateFilm(w http.ResponseWriter, r *http.Request, userID int) {
+	filmID, err := parseIDParam(r, "filmID")
+	if err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	film, ok := a.store.ownedFilm(userID, filmID)
+	if !ok {
+		forbiddenResponse(w)
+		return
+	}
+
+	updated := *film
+	if err = parseRequestBody(r, &updated); err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+	updated.ID, updated.UserID, updated.CreatedAt = film.ID, film.UserID, film.CreatedAt
+	updated.UpdatedAt = time.Now()
+	setDefaultImage(r, &updated)
+
+	if errs := validateFilm(&updated); errs != nil {
+		failedValidationResponse(w, errs)
+		return
+	}
+
+	*film = updated
+	writeJSON(w, http.StatusOK, envelope{"film": film})
+}
+
+// deleteFilm deletes a film owned by the user.
+func (a *API) deleteFilm(w http.ResponseWriter, r *http.Request, userID int) {
+	filmID, err := parseIDParam(r, "filmID")
+	if err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	if _, ok := a.store.ownedFilm(userID, filmID); !ok {
+		forbiddenResponse(w)
+		return
+	}
+	a.store.deleteFilm(filmID)
+
+	writeJSON(w, http.StatusOK, envelope{"message": "film deleted"})
+}
+
+// setDefaultImage sets the image served for films without a poster, as the real API does.
+func setDefaultImage(r *http.Request, film *apiModels.Film) {
+	if film.ImageURL == "" {
+		film.ImageURL = fmt.Sprintf("http://%s/images/%s", r.Host, defaultImageName)
+	}
+}
+
+// validateFilm checks the film against the constraints of the real API.
+func validateFilm(film *apiModels.Film) map[string]string {
+	errs := make(map[string]string)
+
+	checkLength(errs, "title", film.Title, 3, 100)
+	checkLength(errs, "genre", film.Genre, 0, 100)
+	checkLength(errs, "description", film.Description, 0, 1000)
+	checkLength(errs, "comment", film.Comment, 0, 500)
+	checkLength(errs, "review", film.Review, 0, 500)
+
+	if film.Year != 0 && (film.Year < 1888 || film.Year > 2100) {
+		errs["year"] = "must be between 1888 and 2100"
+	}
+	if film.Rating != 0 && (film.Rating < 1 || film.Rating > 10) {
+		errs["rating"] = "must be between 1 and 10"
+	}
+	if film.UserRating != 0 && (film.UserRating < 1 || film.UserRating > 10) {
+		errs["user_rating"] = "must be between 1 and 10"
+	}
+
+	checkURL(errs, "image_url", film.ImageURL)
+	checkURL(errs, "url", film.URL)
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// checkLength records an error if the length of the value in characters is outside the bounds.
+func checkLength(errs map[string]string, field, value string, minLength, maxLength int) {
+	if length := utf8.RuneCountInString(value); length < minLength || length > maxLength {
+		errs[field] = fmt.Sprintf("must be between %d and %d characters", minLength, maxLength)
+	}
+}
+
+// checkURL records an error if the value is set but is not an absolute URL.
+func checkURL(errs map[string]string, field, value string) {
+	if value == "" {
+		return
+	}
+	if u, err := url.ParseRequestURI(value); err != nil || u.Scheme == "" || u.Host == "" {
+		errs[field] = "must be a valid URL"
+	}
+}
@@ -0,0 +1,73 @@
+package fakeapi
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+)
+
+// defaultImageName is the file name of the image assigned to films without a poster.
+const defaultImageName = "default.png"
+
+// uploadImage stores the image from the multipart form and returns its URL.
+func (a *API) uploadImage(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		badRequestResponse(w, fmt.Errorf("error parsing the form: %v", err))
+		return
+	}
+
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		badRequestResponse(w, fmt.Errorf("error receiving the file: %v", err))
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		badRequestResponse(w, fmt.Errorf("error reading the file: %v", err))
+		return
+	}
+
+	filename := fmt.Sprintf("%s.jpg", randomString(8))
+
+	a.store.mu.Lock()
+	a.store.images[filename] = data
+	a.store.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, envelope{"image_url": fmt.Sprintf("http://%s/images/%s", r.Host, filename)})
+}
+
+// getImage serves an uploaded image or the default poster.
+func (a *API) getImage(w http.ResponseWriter, r *http.Request) {
+	filename := r.PathValue("filename")
+
+	a.store.mu.Lock()
+	data, ok := a.store.images[filename]
+	a.store.mu.Unlock()
+
+	if !ok && filename == defaultImageName {
+		data, ok = defaultImage(), true
+	}
+	if !ok {
+		badRequestResponse(w, fmt.Errorf("file not found"))
+		return
+	}
+
+	w.Header().Set("Content-Type", http.DetectContentType(data))
+	_, _ = w.Write(data)
+}
+
+// defaultImage returns a single grey pixel encoded as PNG, standing in for the default poster.
+func defaultImage() []byte {
+	img := image.NewGray(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, color.Gray{Y: 0x80})
+
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, img)
+	return buf.Bytes()
+}
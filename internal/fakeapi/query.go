@@ -0,0 +1,270 @@
+package fakeapi
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/k4sper1love/watchlist-api/pkg/filters"
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultPage     = 1              // Page returned when none is requested.
+	defaultPageSize = 5              // Page size used when none is requested.
+	defaultSort     = "-is_favorite" // Sort order used when none is requested.
+	maxPage         = 10_000_000     // Largest page number accepted by the API.
+	maxPageSize     = 100            // Largest page size accepted by the API.
+)
+
+// pagination holds the requested page, page size and sort order.
+type pagination struct {
+	page     int    // Requested page, starting from 1.
+	pageSize int    // Number of records per page.
+	sort     string // Sort column, prefixed with '-' for descending order.
+}
+
+// filmsQuery holds the filters of a films request.
+type filmsQuery struct {
+	pagination
+	title             string     // Case-insensitive substring of the title.
+	rating            valueRange // Range of the rating.
+	userRating        valueRange // Range of the user rating.
+	year              valueRange // Range of the release year.
+	isViewed          *bool      // Whether the film is viewed, if set.
+	isFavorite        *bool      // Whether the film is favorite, if set.
+	hasURL            *bool      // Whether the film has a URL, if set.
+	excludeCollection int        // ID of a collection whose films are excluded, or -1.
+}
+
+// collectionsQuery holds the filters of a collections request.
+type collectionsQuery struct {
+	pagination
+	name        string // Case-insensitive substring of the name.
+	film        int    // ID of a film the collections must contain, or -1.
+	excludeFilm int    // ID of a film the collections must not contain, or -1.
+}
+
+// valueRange is an inclusive range parsed from an exact value or a "min-max" string.
+type valueRange struct {
+	set      bool    // Whether the filter is set.
+	min, max float64 // Bounds of the range.
+}
+
+// filmSortColumns lists the columns films can be sorted by.
+var filmSortColumns = []string{"id", "title", "rating", "year", "is_viewed", "is_favorite", "user_rating", "created_at"}
+
+// collectionSortColumns lists the columns collections can be sorted by.
+var collectionSortColumns = []string{"id", "name", "created_at", "total_films", "is_favorite"}
+
+// parseFilmsQuery parses and validates the query parameters of a films request.
+func parseFilmsQuery(qs url.Values) (filmsQuery, map[string]string) {
+	errs := make(map[string]string)
+
+	query := filmsQuery{
+		pagination:        parsePagination(qs, filmSortColumns, errs),
+		title:             qs.Get("title"),
+		rating:            parseRange(qs, "rating", errs),
+		userRating:        parseRange(qs, "user_rating", errs),
+		year:              parseRange(qs, "year", errs),
+		isViewed:          parseBoolPtr(qs, "is_viewed"),
+		isFavorite:        parseBoolPtr(qs, "is_favorite"),
+		hasURL:            parseBoolPtr(qs, "has_url"),
+		excludeCollection: parseInt(qs, "exclude_collection", -1),
+	}
+
+	if len(errs) > 0 {
+		return query, errs
+	}
+	return query, nil
+}
+
+// parseCollectionsQuery parses and validates the query parameters of a collections request.
+func parseCollectionsQuery(qs url.Values) (collectionsQuery, map[string]string) {
+	errs := make(map[string]string)
+
+	query := collectionsQuery{
+		pagination:  parsePagination(qs, collectionSortColumns, errs),
+		name:        qs.Get("name"),
+		film:        parseInt(qs, "film", -1),
+		excludeFilm: parseInt(qs, "exclude_film", -1),
+	}
+
+	if len(errs) > 0 {
+		return query, errs
+	}
+	return query, nil
+}
+
+// parsePagination parses the page, page size and sort order, recording validation errors.
+func parsePagination(qs url.Values, sortColumns []string, errs map[string]string) pagination {
+	p := pagination{
+		page:     parseInt(qs, "page", defaultPage),
+		pageSize: parseInt(qs, "page_size", defaultPageSize),
+		sort:     defaultSort,
+	}
+	if sort := qs.Get("sort"); sort != "" {
+		p.sort = sort
+	}
+
+	if p.page < 1 || p.page > maxPage {
+		errs["page"] = fmt.Sprintf("must be between 1 and %d", maxPage)
+	}
+	if p.pageSize < 1 || p.pageSize > maxPageSize {
+		errs["page_size"] = fmt.Sprintf("must be between 1 and %d", maxPageSize)
+	}
+	if !slices.Contains(sortColumns, strings.TrimPrefix(p.sort, "-")) {
+		errs["sort"] = "invalid sort value"
+	}
+	return p
+}
+
+// parseRange parses an exact value or a "min-max" range, recording a validation error if it is malformed.
+func parseRange(qs url.Values, key string, errs map[string]string) valueRange {
+	value := qs.Get(key)
+	if value == "" {
+		return valueRange{}
+	}
+
+	bounds := strings.Split(value, "-")
+	if len(bounds) > 2 {
+		errs[key] = "invalid range format"
+		return valueRange{}
+	}
+
+	r := valueRange{set: true}
+	var err error
+	if r.min, err = strconv.ParseFloat(strings.TrimSpace(bounds[0]), 64); err != nil {
+		errs[key] = "invalid value"
+		return valueRange{}
+	}
+	r.max = r.min
+	if len(bounds) == 2 {
+		if r.max, err = strconv.ParseFloat(strings.TrimSpace(bounds[1]), 64); err != nil {
+			errs[key] = "invalid value"
+			return valueRange{}
+		}
+	}
+	return r
+}
+
+// contains reports whether the value is within the range or the range is not set.
+func (r valueRange) contains(value float64) bool {
+	return !r.set || (value >= r.min && value <= r.max)
+}
+
+// parseInt parses an integer query parameter, returning the default if it is missing or malformed.
+func parseInt(qs url.Values, key string, defaultValue int) int {
+	value, err := strconv.Atoi(qs.Get(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// parseBoolPtr parses an optional boolean query parameter.
+func parseBoolPtr(qs url.Values, key string) *bool {
+	value, err := strconv.ParseBool(qs.Get(key))
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+// matchFilm reports whether the film passes the filters of the query.
+// Membership in the excluded collection is checked by the caller.
+func (q filmsQuery) matchFilm(film *apiModels.Film) bool {
+	return containsFold(film.Title, q.title) &&
+		q.rating.contains(film.Rating) &&
+		q.userRating.contains(film.UserRating) &&
+		q.year.contains(float64(film.Year)) &&
+		matchBool(q.isViewed, film.IsViewed) &&
+		matchBool(q.isFavorite, film.IsFavorite) &&
+		matchBool(q.hasURL, film.URL != "")
+}
+
+// sortFilms sorts the films by the requested column, breaking ties by ID.
+func sortFilms(films []apiModels.Film, sort string) {
+	column, desc := strings.TrimPrefix(sort, "-"), strings.HasPrefix(sort, "-")
+
+	slices.SortStableFunc(films, func(a, b apiModels.Film) int {
+		var c int
+		switch column {
+		case "title":
+			c = cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		case "rating":
+			c = cmp.Compare(a.Rating, b.Rating)
+		case "year":
+			c = cmp.Compare(a.Year, b.Year)
+		case "is_viewed":
+			c = compareBool(a.IsViewed, b.IsViewed)
+		case "is_favorite":
+			c = compareBool(a.IsFavorite, b.IsFavorite)
+		case "user_rating":
+			c = cmp.Compare(a.UserRating, b.UserRating)
+		case "created_at":
+			c = a.CreatedAt.Compare(b.CreatedAt)
+		}
+		if desc {
+			c = -c
+		}
+		return cmp.Or(c, cmp.Compare(a.ID, b.ID))
+	})
+}
+
+// sortCollections sorts the collections by the requested column, breaking ties by the number of films
+// in descending order and then by ID.
+func sortCollections(collections []apiModels.Collection, sort string) {
+	column, desc := strings.TrimPrefix(sort, "-"), strings.HasPrefix(sort, "-")
+
+	slices.SortStableFunc(collections, func(a, b apiModels.Collection) int {
+		var c int
+		switch column {
+		case "name":
+			c = cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		case "created_at":
+			c = a.CreatedAt.Compare(b.CreatedAt)
+		case "total_films":
+			c = cmp.Compare(a.TotalFilms, b.TotalFilms)
+		case "is_favorite":
+			c = compareBool(a.IsFavorite, b.IsFavorite)
+		}
+		if desc {
+			c = -c
+		}
+		return cmp.Or(c, -cmp.Compare(a.TotalFilms, b.TotalFilms), cmp.Compare(a.ID, b.ID))
+	})
+}
+
+// paginate returns the requested page of records with its pagination metadata.
+func paginate[T any](records []T, p pagination) ([]T, filters.Metadata) {
+	metadata := filters.CalculateMetadata(len(records), p.page, p.pageSize)
+
+	start := min((p.page-1)*p.pageSize, len(records))
+	end := min(start+p.pageSize, len(records))
+	return records[start:end], metadata
+}
+
+// containsFold reports whether substr is within s, ignoring case.
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// matchBool reports whether the value equals the filter or the filter is not set.
+func matchBool(filter *bool, value bool) bool {
+	return filter == nil || *filter == value
+}
+
+// compareBool orders false before true.
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
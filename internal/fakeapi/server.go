@@ -0,0 +1,206 @@
+package fakeapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultAccessTokenTTL  = time.Hour      // Default lifetime of access tokens, as in the real API.
+	defaultRefreshTokenTTL = 48 * time.Hour // Default lifetime of refresh tokens, as in the real API.
+)
+
+// Options configures the fake API.
+type Options struct {
+	Secret          string        // Secret used to verify Telegram verification tokens (the bot's API_SECRET).
+	AccessTokenTTL  time.Duration // Lifetime of issued access tokens.
+	RefreshTokenTTL time.Duration // Lifetime of issued refresh tokens.
+}
+
+// API is an in-memory implementation of the Watchlist API.
+// It implements http.Handler and is safe for concurrent use.
+type API struct {
+	secret          string         // Secret used to verify Telegram verification tokens.
+	accessSecret    string         // Secret used to sign access tokens.
+	refreshSecret   string         // Secret used to sign refresh tokens.
+	accessTokenTTL  time.Duration  // Lifetime of issued access tokens.
+	refreshTokenTTL time.Duration  // Lifetime of issued refresh tokens.
+	store           *store         // In-memory storage of users, films and collections.
+	mux             *http.ServeMux // Router serving the API endpoints.
+	available       bool           // Whether the API answers requests or responds with 503.
+	mu              sync.RWMutex   // Mutex guarding the availability flag and the access token secret.
+}
+
+// Server is a fake API running on a local httptest server.
+type Server struct {
+	*httptest.Server      // Underlying test server; its URL is the API base URL.
+	API              *API // API served by the server.
+}
+
+// envelope is a map used for formatting JSON responses.
+type envelope map[string]any
+
+// New creates an empty fake API.
+func New(options Options) *API {
+	if options.AccessTokenTTL <= 0 {
+		options.AccessTokenTTL = defaultAccessTokenTTL
+	}
+	if options.RefreshTokenTTL <= 0 {
+		options.RefreshTokenTTL = defaultRefreshTokenTTL
+	}
+
+	api := &API{
+		secret:          options.Secret,
+		accessSecret:    randomString(32),
+		refreshSecret:   randomString(32),
+		accessTokenTTL:  options.AccessTokenTTL,
+		refreshTokenTTL: options.RefreshTokenTTL,
+		store:           newStore(),
+		mux:             http.NewServeMux(),
+		available:       true,
+	}
+	api.route()
+
+	return api
+}
+
+// NewServer creates a fake API and starts serving it on a local httptest server.
+// The caller must close the server when finished.
+func NewServer(options Options) *Server {
+	api := New(options)
+	return &Server{Server: httptest.NewServer(api), API: api}
+}
+
+// ServeHTTP serves a request, responding with 503 while the API is marked unavailable.
+func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !a.Available() {
+		errorResponse(w, http.StatusServiceUnavailable, "the service is temporarily unavailable")
+		return
+	}
+	a.mux.ServeHTTP(w, r)
+}
+
+// Available reports whether the API answers requests.
+func (a *API) Available() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.available
+}
+
+// SetAvailable makes the API answer requests normally or respond to every request with 503,
+// simulating an outage of the real API.
+func (a *API) SetAvailable(available bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.available = available
+}
+
+// route registers the API endpoints.
+func (a *API) route() {
+	a.mux.HandleFunc("GET /api/v1/healthcheck", a.healthcheck)
+
+	a.mux.HandleFunc("POST /upload", a.uploadImage)
+	a.mux.HandleFunc("GET /images/{filename}", a.getImage)
+
+	a.mux.HandleFunc("POST /api/v1/auth/register/telegram", a.verify(a.registerByTelegram))
+	a.mux.HandleFunc("POST /api/v1/auth/login/telegram", a.verify(a.loginByTelegram))
+	a.mux.HandleFunc("POST /api/v1/auth/refresh", a.refreshAccessToken)
+	a.mux.HandleFunc("POST /api/v1/auth/logout", a.logout)
+	a.mux.HandleFunc("GET /api/v1/auth/check", a.authenticate(a.checkToken))
+
+	a.mux.HandleFunc("GET /api/v1/user", a.authenticate(a.getUser))
+	a.mux.HandleFunc("PUT /api/v1/user", a.authenticate(a.updateUser))
+	a.mux.HandleFunc("DELETE /api/v1/user", a.authenticate(a.deleteUser))
+
+	a.mux.HandleFunc("GET /api/v1/films", a.authenticate(a.getFilms))
+	a.mux.HandleFunc("POST /api/v1/films", a.authenticate(a.addFilm))
+	a.mux.HandleFunc("GET /api/v1/films/{filmID}", a.authenticate(a.getFilm))
+	a.mux.HandleFunc("PUT /api/v1/films/{filmID}", a.authenticate(a.updateFilm))
+	a.mux.HandleFunc("DELETE /api/v1/films/{filmID}", a.authenticate(a.deleteFilm))
+
+	a.mux.HandleFunc("GET /api/v1/collections", a.authenticate(a.getCollections))
+	a.mux.HandleFunc("POST /api/v1/collections", a.authenticate(a.addCollection))
+	a.mux.HandleFunc("GET /api/v1/collections/{collectionID}", a.authenticate(a.getCollection))
+	a.mux.HandleFunc("PUT /api/v1/collections/{collectionID}", a.authenticate(a.updateCollection))
+	a.mux.HandleFunc("DELETE /api/v1/collections/{collectionID}", a.authenticate(a.deleteCollection))
+
+	a.mux.HandleFunc("GET /api/v1/collections/{collectionID}/films", a.authenticate(a.getCollectionFilms))
+	a.mux.HandleFunc("POST /api/v1/collections/{collectionID}/films", a.authenticate(a.addNewCollectionFilm))
+	a.mux.HandleFunc("POST /api/v1/collections/{collectionID}/films/{filmID}", a.authenticate(a.addCollectionFilm))
+	a.mux.HandleFunc("GET /api/v1/collections/{collectionID}/films/{filmID}", a.authenticate(a.getCollectionFilm))
+	a.mux.HandleFunc("DELETE /api/v1/collections/{collectionID}/films/{filmID}", a.authenticate(a.deleteCollectionFilm))
+}
+
+// healthcheck reports that the API is available.
+func (a *API) healthcheck(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, envelope{"status": "available"})
+}
+
+// writeJSON encodes the data as JSON and writes it with the given status code.
+func writeJSON(w http.ResponseWriter, status int, data envelope) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		slog.Error("failed to encode fake API response", slog.Any("error", err))
+	}
+}
+
+// errorResponse writes an error message in the envelope used by the real API.
+func errorResponse(w http.ResponseWriter, status int, message any) {
+	writeJSON(w, status, envelope{"error": message})
+}
+
+// notFoundResponse reports a missing resource.
+func notFoundResponse(w http.ResponseWriter) {
+	errorResponse(w, http.StatusNotFound, "resource not found")
+}
+
+// forbiddenResponse reports an attempt to access a resource owned by another user.
+func forbiddenResponse(w http.ResponseWriter) {
+	errorResponse(w, http.StatusForbidden, "you don't have enough permissions to perform this action")
+}
+
+// badRequestResponse reports a malformed request.
+func badRequestResponse(w http.ResponseWriter, err error) {
+	errorResponse(w, http.StatusBadRequest, err.Error())
+}
+
+// failedValidationResponse reports invalid fields of a request.
+func failedValidationResponse(w http.ResponseWriter, errs map[string]string) {
+	errorResponse(w, http.StatusUnprocessableEntity, errs)
+}
+
+// parseRequestBody decodes the JSON body of the request into the target.
+func parseRequestBody(r *http.Request, target any) error {
+	if err := json.NewDecoder(r.Body).Decode(target); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+// parseIDParam extracts a positive integer ID from a path parameter.
+func parseIDParam(r *http.Request, name string) (int, error) {
+	id, err := strconv.Atoi(r.PathValue(name))
+	if err != nil || id < 1 {
+		return 0, fmt.Errorf("invalid id parameter")
+	}
+	return id, nil
+}
+
+// randomString returns a random hex string of n bytes.
+func randomString(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,156 @@
+package fakeapi
+
+import (
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"sync"
+	"time"
+)
+
+// store keeps the data of the fake API in memory.
+type store struct {
+	users            map[int]*apiModels.User             // Users keyed by ID.
+	telegramUsers    map[int]int                         // User IDs keyed by Telegram ID.
+	refreshTokens    map[string]int                      // User IDs keyed by active refresh tokens.
+	films            map[int]*apiModels.Film             // Films keyed by ID.
+	collections      map[int]*apiModels.Collection       // Collections keyed by ID.
+	collectionFilms  map[int]map[int]collectionFilmEntry // Collection memberships keyed by collection ID and film ID.
+	images           map[string][]byte                   // Uploaded images keyed by file name.
+	nextUserID       int                                 // ID assigned to the next user.
+	nextFilmID       int                                 // ID assigned to the next film.
+	nextCollectionID int                                 // ID assigned to the next collection.
+	mu               sync.Mutex                          // Mutex guarding the stored data.
+}
+
+// collectionFilmEntry records when a film was added to a collection.
+type collectionFilmEntry struct {
+	addedAt   time.Time // Time the film was added to the collection.
+	updatedAt time.Time // Time the membership was last updated.
+}
+
+// newStore creates an empty store.
+func newStore() *store {
+	return &store{
+		users:            make(map[int]*apiModels.User),
+		telegramUsers:    make(map[int]int),
+		refreshTokens:    make(map[string]int),
+		films:            make(map[int]*apiModels.Film),
+		collections:      make(map[int]*apiModels.Collection),
+		collectionFilms:  make(map[int]map[int]collectionFilmEntry),
+		images:           make(map[string][]byte),
+		nextUserID:       1,
+		nextFilmID:       1,
+		nextCollectionID: 1,
+	}
+}
+
+// addUser creates a user for the Telegram ID.
+func (s *store) addUser(telegramID int, username string) *apiModels.User {
+	user := &apiModels.User{
+		ID:         s.nextUserID,
+		TelegramID: telegramID,
+		Username:   username,
+		CreatedAt:  time.Now(),
+	}
+	s.nextUserID++
+
+	s.users[user.ID] = user
+	s.telegramUsers[telegramID] = user.ID
+	return user
+}
+
+// deleteUser removes the user together with their films, collections and tokens.
+func (s *store) deleteUser(userID int) {
+	for id, film := range s.films {
+		if film.UserID == userID {
+			s.deleteFilm(id)
+		}
+	}
+	for id, collection := range s.collections {
+		if collection.UserID == userID {
+			s.deleteCollection(id)
+		}
+	}
+	for token, id := range s.refreshTokens {
+		if id == userID {
+			delete(s.refreshTokens, token)
+		}
+	}
+
+	delete(s.telegramUsers, s.users[userID].TelegramID)
+	delete(s.users, userID)
+}
+
+// addFilm stores a new film and assigns it an ID and timestamps.
+func (s *store) addFilm(film apiModels.Film) *apiModels.Film {
+	film.ID = s.nextFilmID
+	film.CreatedAt = time.Now()
+	film.UpdatedAt = film.CreatedAt
+	s.nextFilmID++
+
+	s.films[film.ID] = &film
+	return &film
+}
+
+// deleteFilm removes the film and its collection memberships.
+func (s *store) deleteFilm(filmID int) {
+	for _, films := range s.collectionFilms {
+		delete(films, filmID)
+	}
+	delete(s.films, filmID)
+}
+
+// addCollection stores a new collection and assigns it an ID and timestamps.
+func (s *store) addCollection(collection apiModels.Collection) *apiModels.Collection {
+	collection.ID = s.nextCollectionID
+	collection.CreatedAt = time.Now()
+	collection.UpdatedAt = collection.CreatedAt
+	s.nextCollectionID++
+
+	s.collections[collection.ID] = &collection
+	s.collectionFilms[collection.ID] = make(map[int]collectionFilmEntry)
+	return &collection
+}
+
+// deleteCollection removes the collection and its memberships.
+func (s *store) deleteCollection(collectionID int) {
+	delete(s.collectionFilms, collectionID)
+	delete(s.collections, collectionID)
+}
+
+// collection returns a copy of the collection with its current number of films.
+func (s *store) collection(collectionID int) apiModels.Collection {
+	collection := *s.collections[collectionID]
+	collection.TotalFilms = len(s.collectionFilms[collectionID])
+	return collection
+}
+
+// addCollectionFilm adds the film to the collection and returns false if it is already there.
+func (s *store) addCollectionFilm(collectionID, filmID int) (collectionFilmEntry, bool) {
+	if _, ok := s.collectionFilms[collectionID][filmID]; ok {
+		return collectionFilmEntry{}, false
+	}
+
+	now := time.Now()
+	entry := collectionFilmEntry{addedAt: now, updatedAt: now}
+	s.collectionFilms[collectionID][filmID] = entry
+	return entry, true
+}
+
+// ownedFilm returns the film if it exists and belongs to the user.
+// The boolean reports whether access is allowed; like the real API, a missing film is forbidden too.
+func (s *store) ownedFilm(userID, filmID int) (*apiModels.Film, bool) {
+	film, ok := s.films[filmID]
+	if !ok || film.UserID != userID {
+		return nil, false
+	}
+	return film, true
+}
+
+// ownedCollection returns the collection if it exists and belongs to the user.
+func (s *store) ownedCollection(userID, collectionID int) (*apiModels.Collection, bool) {
+	collection, ok := s.collections[collectionID]
+	if !ok || collection.UserID != userID {
+		return nil, false
+	}
+	return collection, true
+}
@@ -0,0 +1,62 @@
+package fakeapi
+
+import (
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"net/http"
+)
+
+// getUser returns the authenticated user.
+func (a *API) getUser(w http.ResponseWriter, _ *http.Request, userID int) {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, envelope{"user": a.store.users[userID]})
+}
+
+// updateUser applies the fields from the request body to the authenticated user.
+func (a *API) updateUser(w http.ResponseWriter, r *http.Request, userID int) {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	user := a.store.users[userID]
+
+	updated := *user
+	if err := parseRequestBody(r, &updated); err != nil {
+		badRequestResponse(w, err)
+		return
+	}
+	updated.ID, updated.TelegramID, updated.CreatedAt, updated.Password = user.ID, user.TelegramID, user.CreatedAt, ""
+
+	if errs := validateUser(&updated); errs != nil {
+		failedValidationResponse(w, errs)
+		return
+	}
+
+	*user = updated
+	writeJSON(w, http.StatusOK, envelope{"user": user})
+}
+
+// deleteUser deletes the authenticated user together with their films and collections.
+func (a *API) deleteUser(w http.ResponseWriter, _ *http.Request, userID int) {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	a.store.deleteUser(userID)
+
+	writeJSON(w, http.StatusOK, envelope{"message": "user deleted"})
+}
+
+// validateUser checks the user against the constraints of the real API.
+func validateUser(user *apiModels.User) map[string]string {
+	errs := make(map[string]string)
+
+	checkLength(errs, "username", user.Username, 3, 20)
+	if user.Email != "" {
+		checkLength(errs, "email", user.Email, 6, 254)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package admin
+
+import (
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/router"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/states"
+	"github.com/k4sper1love/watchlist-bot/pkg/roles"
+)
+
+// Routes returns the routes handled by the admin package.
+func Routes() []router.Route {
+	return []router.Route{
+		{Commands: []string{"admin"}, Callbacks: []string{states.CallMenuAdmin}, Role: roles.Helper, Handler: HandleMenuCommand},
+		{Callbacks: []string{states.Admin}, Role: roles.Helper, Handler: HandleMenuButton},
+		{Callbacks: []string{states.AdminDetail}, Role: roles.SuperAdmin, Handler: HandleAdminDetailButtons},
+		{Callbacks: []string{states.UserDetail}, Role: roles.Helper, Handler: HandleUserDetailButton},
+		{Callbacks: []string{states.Feedbacks, states.SelectFeedback}, Role: roles.Helper, Handler: HandleFeedbacksButtons},
+		{Callbacks: []string{states.Entities, states.SelectEntity}, Role: roles.Helper, Handler: HandleEntitiesButtons},
+		{Callbacks: []string{states.FeedbackDetail}, Role: roles.Helper, Handler: HandleFeedbackDetailButtons},
+		{States: []string{states.UserDetailAwait}, Role: roles.Admin, Handler: HandleUserDetailProcess},
+		{States: []string{states.EntitiesAwait}, Role: roles.Admin, Handler: HandleEntitiesProcess},
+		{States: []string{states.BroadcastAwait}, Role: roles.Admin, Handler: HandleBroadcastProcess},
+	}
+}
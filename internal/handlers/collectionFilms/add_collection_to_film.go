@@ -136,7 +136,7 @@ func parseAddCollectionToFilmName(app models.App, session *models.Session) {
 
 // getCollectionsExcludeFilm retrieves a paginated list of collections excluding the current film.
 func getCollectionsExcludeFilm(app models.App, session *models.Session) (*filters.Metadata, error) {
-	collectionsResponse, err := watchlist.GetCollectionsExcludeFilm(app, session)
+	collectionsResponse, err := watchlist.GetClient().GetCollectionsExcludeFilm(app.Context(), session)
 	if err != nil {
 		return nil, err
 	}
@@ -148,6 +148,7 @@ func getCollectionsExcludeFilm(app models.App, session *models.Session) (*filter
 // updateSessionWithCollectionsExcludeFilm updates the session with the retrieved collections and their metadata.
 func updateSessionWithCollectionsExcludeFilm(session *models.Session, collectionsResponse *models.CollectionsResponse) {
 	session.CollectionsState.Collections = collectionsResponse.Collections
+	session.CollectionsState.LoadedPage = 0 // The collections exclude a film, so they are never shown as the cached collections list.
 	session.CollectionFilmsState.CurrentPage = collectionsResponse.Metadata.CurrentPage
 	session.CollectionFilmsState.LastPage = collectionsResponse.Metadata.LastPage
 	session.CollectionFilmsState.TotalRecords = collectionsResponse.Metadata.TotalRecords
@@ -135,7 +135,7 @@ func parseAddFilmToCollectionTitle(app models.App, session *models.Session) {
 
 // getFilmsExcludeCollection retrieves a paginated list of films excluding the current collection.
 func getFilmsExcludeCollection(app models.App, session *models.Session) (*filters.Metadata, error) {
-	filmsResponse, err := watchlist.GetFilmsExcludeCollection(app, session)
+	filmsResponse, err := watchlist.GetClient().GetFilmsExcludeCollection(app.Context(), session)
 	if err != nil {
 		return nil, err
 	}
@@ -147,6 +147,7 @@ func getFilmsExcludeCollection(app models.App, session *models.Session) (*filter
 // updateSessionWithFilmsExcludeCollection updates the session with the retrieved films and their metadata.
 func updateSessionWithFilmsExcludeCollection(session *models.Session, filmsResponse *models.FilmsResponse) {
 	session.FilmsState.Films = filmsResponse.Films
+	session.FilmsState.LoadedFrom = "" // The films exclude a collection, so they are never shown as the cached films list.
 	session.CollectionFilmsState.CurrentPage = filmsResponse.Metadata.CurrentPage
 	session.CollectionFilmsState.LastPage = filmsResponse.Metadata.LastPage
 }
@@ -35,7 +35,7 @@ func handleFilmsWithContext(app models.App, session *models.Session) {
 // AddFilmToCollection adds a film to a collection using the Watchlist service.
 // Sends a success message upon completion and clears the session states.
 func AddFilmToCollection(app models.App, session *models.Session) {
-	collectionFilm, err := watchlist.AddCollectionFilm(app, session)
+	collectionFilm, err := watchlist.GetClient().AddCollectionFilm(app.Context(), session)
 	if err != nil {
 		app.SendMessage(messages.CreateFilmFailure(session), keyboards.Back(session, states.CallMenuFilms))
 		return
@@ -0,0 +1,31 @@
+package collectionFilms
+
+import (
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/router"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/states"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+)
+
+// Routes returns the routes handled by the collectionFilms package.
+func Routes() []router.Route {
+	return []router.Route{
+		{Callbacks: []string{states.CollectionFilmsFrom}, Writes: true, Handler: handleCollectionFilmsFrom},
+		{Callbacks: []string{states.FilmToCollectionOption}, Writes: true, Handler: handleFilmToCollectionOption},
+		{Callbacks: []string{states.AddCollectionToFilm, states.SelectCFCollection}, Writes: true, Handler: HandleAddCollectionToFilmButtons},
+		{Callbacks: []string{states.AddFilmToCollection, states.SelectCFFilm}, Writes: true, Handler: HandleAddFilmToCollectionButtons},
+		{States: []string{states.AddFilmToCollectionAwait}, Writes: true, Handler: HandleAddFilmToCollectionProcess},
+		{States: []string{states.AddCollectionToFilmAwait}, Writes: true, Handler: HandleAddCollectionToFilmProcess},
+	}
+}
+
+// handleCollectionFilmsFrom resets the pagination and handles the collection films buttons.
+func handleCollectionFilmsFrom(app models.App, session *models.Session) {
+	session.CollectionFilmsState.CurrentPage = 1
+	HandleCollectionFilmsButtons(app, session)
+}
+
+// handleFilmToCollectionOption resets the pagination and handles the film-to-collection option buttons.
+func handleFilmToCollectionOption(app models.App, session *models.Session) {
+	session.CollectionFilmsState.CurrentPage = 1
+	HandleOptionsFilmToCollectionButtons(app, session)
+}
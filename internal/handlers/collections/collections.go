@@ -22,13 +22,35 @@ func HandleCollectionsCommand(app models.App, session *models.Session) {
 	// Clears the name used for finding collections in other contexts to ensure a fresh state.
 	session.CollectionsState.Clear()
 
-	if metadata, err := getCollections(app, session); err != nil {
+	if metadata, err := getCollections(app, session); watchlist.IsUnavailable(err) {
+		handleCollectionsUnavailable(app, session)
+	} else if err != nil {
 		app.SendMessage(messages.CollectionsFailure(session), keyboards.Back(session, ""))
 	} else {
 		app.SendMessage(messages.Collections(session, metadata, false), keyboards.Collections(session, metadata.CurrentPage, metadata.LastPage))
 	}
 }
 
+// handleCollectionsUnavailable shows the collections loaded last read-only while the Watchlist service is unavailable.
+func handleCollectionsUnavailable(app models.App, session *models.Session) {
+	state := session.CollectionsState
+	if len(state.Collections) == 0 || state.LoadedPage == 0 {
+		app.SendMessage(messages.ServiceUnavailable(session), keyboards.Back(session, ""))
+		return
+	}
+
+	// Return to the page of the cached collections, since pagination may have moved past it.
+	session.CollectionsState.CurrentPage = state.LoadedPage
+	metadata := &filters.Metadata{
+		CurrentPage:  state.LoadedPage,
+		PageSize:     state.PageSize,
+		LastPage:     state.LastPage,
+		TotalRecords: state.TotalRecords,
+	}
+
+	app.SendMessage(messages.ServiceDegraded(session)+messages.Collections(session, metadata, false), keyboards.CollectionsReadOnly(session))
+}
+
 // HandleCollectionsButtons handles button interactions related to collections.
 // Supports actions like going back, creating new collections, managing existing ones, searching, sorting, and pagination.
 func HandleCollectionsButtons(app models.App, session *models.Session) {
@@ -141,7 +163,7 @@ func handleFavoriteCollection(app models.App, session *models.Session) {
 
 // getCollections retrieves a paginated list of collections using the Watchlist service.
 func getCollections(app models.App, session *models.Session) (*filters.Metadata, error) {
-	collectionsResponse, err := watchlist.GetCollections(app, session)
+	collectionsResponse, err := watchlist.GetClient().GetCollections(app.Context(), session)
 	if err != nil {
 		return nil, err
 	}
@@ -154,6 +176,8 @@ func getCollections(app models.App, session *models.Session) (*filters.Metadata,
 func updateSessionWithCollections(session *models.Session, collections []apiModels.Collection, metadata *filters.Metadata) {
 	session.CollectionsState.Collections = collections
 	session.CollectionsState.LastPage = metadata.LastPage
+	session.CollectionsState.TotalRecords = metadata.TotalRecords
+	session.CollectionsState.LoadedPage = metadata.CurrentPage
 }
 
 // setContextAndHandleFilms sets the context to "collection" and navigates to the films associated with the collection.
@@ -34,7 +34,7 @@ func parseDeleteCollectionConfirm(app models.App, session *models.Session) {
 		return
 	}
 
-	if err := watchlist.DeleteCollection(app, session); err != nil {
+	if err := watchlist.GetClient().DeleteCollection(app.Context(), session); err != nil {
 		app.SendMessage(messages.DeleteCollectionFailure(session), keyboards.Back(session, states.CallCollectionsManage))
 		return
 	}
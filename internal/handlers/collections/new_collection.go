@@ -47,7 +47,7 @@ func requestNewCollectionDescription(app models.App, session *models.Session) {
 // It sends the collection data to the Watchlist service, clears the session states,
 // and processes the next steps based on the session context.
 func finishNewCollectionProcess(app models.App, session *models.Session) {
-	collection, err := watchlist.CreateCollection(app, session)
+	collection, err := watchlist.GetClient().CreateCollection(app.Context(), session)
 	session.ClearAllStates() // Clears session states before processing the collection context
 	if err != nil {
 		app.SendMessage(messages.CreateCollectionFailure(session), keyboards.Back(session, states.CallCollectionsNew))
@@ -0,0 +1,30 @@
+package collections
+
+import (
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/router"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/states"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+)
+
+// Routes returns the routes handled by the collections package.
+func Routes() []router.Route {
+	return []router.Route{
+		{Commands: []string{"collections"}, Callbacks: []string{states.CallMenuCollections}, Handler: handleCollectionsMenu},
+		{Callbacks: []string{states.Collections, states.SelectCollection}, Handler: HandleCollectionsButtons},
+		{Callbacks: []string{states.CollectionSorting}, Handler: HandleSortingCollectionsButtons},
+		{Callbacks: []string{states.FindCollections}, Handler: HandleFindCollectionsButtons},
+		{Callbacks: []string{states.ManageCollection}, Writes: true, Handler: HandleManageCollectionButtons},
+		{Callbacks: []string{states.UpdateCollection}, Writes: true, Handler: HandleUpdateCollectionButtons},
+		{States: []string{states.CollectionSortingAwait}, Handler: HandleSortingCollectionsProcess},
+		{States: []string{states.CollectionsAwait}, Handler: HandleCollectionProcess},
+		{States: []string{states.NewCollectionAwait}, Writes: true, Handler: HandleNewCollectionProcess},
+		{States: []string{states.UpdateCollectionAwait}, Writes: true, Handler: HandleUpdateCollectionProcess},
+		{States: []string{states.DeleteCollectionAwait}, Writes: true, Handler: HandleDeleteCollectionProcess},
+	}
+}
+
+// handleCollectionsMenu opens the user's collections list from the first page.
+func handleCollectionsMenu(app models.App, session *models.Session) {
+	session.CollectionsState.CurrentPage = 1
+	HandleCollectionsCommand(app, session)
+}
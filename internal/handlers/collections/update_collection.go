@@ -68,7 +68,9 @@ func handleUpdateCollectionDescription(app models.App, session *models.Session)
 
 // HandleUpdateCollection updates the collection using the Watchlist service and resets the session state.
 func HandleUpdateCollection(app models.App, session *models.Session, back func(models.App, *models.Session)) {
-	if err := updateCollectionAndState(app, session); err != nil {
+	if err := updateCollectionAndState(app, session); watchlist.IsUnavailable(err) {
+		app.SendMessage(messages.ReadOnlyMode(session), nil)
+	} else if err != nil {
 		app.SendMessage(messages.UpdateCollectionFailure(session), nil)
 	} else {
 		app.SendMessage(messages.UpdateCollectionSuccess(session), nil)
@@ -80,7 +82,7 @@ func HandleUpdateCollection(app models.App, session *models.Session, back func(m
 
 // updateCollectionAndState updates the collection in the database and synchronizes the session state with the updated data.
 func updateCollectionAndState(app models.App, session *models.Session) error {
-	collection, err := watchlist.UpdateCollection(app, session)
+	collection, err := watchlist.GetClient().UpdateCollection(app.Context(), session)
 	if err != nil {
 		return err
 	}
@@ -48,9 +48,9 @@ func handleDeleteFilmConfirm(app models.App, session *models.Session) {
 func DeleteFilm(app models.App, session *models.Session) error {
 	switch session.Context {
 	case states.CtxFilm:
-		return watchlist.DeleteFilm(app, session)
+		return watchlist.GetClient().DeleteFilm(app.Context(), session)
 	case states.CtxCollection:
-		return watchlist.DeleteCollectionFilm(app, session)
+		return watchlist.GetClient().DeleteCollectionFilm(app.Context(), session)
 	default:
 		return fmt.Errorf("unsupported session context: %s", session.Context)
 	}
@@ -24,7 +24,7 @@ func HandleFilmDetailCommand(app models.App, session *models.Session) {
 }
 
 // HandleFilmDetailButtons handles button interactions related to the detailed view of a film.
-// Supports actions like going back, marking as viewed, adding to favorites, and pagination.
+// Supports actions like going back, marking as viewed or the next episode as watched, adding to favorites, and pagination.
 func HandleFilmDetailButtons(app models.App, session *models.Session) {
 	callback := utils.ParseCallback(app.Update)
 
@@ -39,6 +39,9 @@ func HandleFilmDetailButtons(app models.App, session *models.Session) {
 	case states.CallFilmDetailFavorite:
 		makeFavoriteFilm(app, session)
 
+	case states.CallFilmDetailEpisode:
+		HandleNextEpisodeCommand(app, session)
+
 	default:
 		if strings.HasPrefix(callback, states.FilmDetailPage) {
 			handleFilmDetailPagination(app, session, callback)
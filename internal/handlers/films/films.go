@@ -22,13 +22,35 @@ func HandleFilmsCommand(app models.App, session *models.Session) {
 	// Clears the title used for finding films in other contexts to ensure a fresh state.
 	session.FilmsState.Clear()
 
-	if metadata, err := getFilms(app, session); err != nil {
+	if metadata, err := getFilms(app, session); watchlist.IsUnavailable(err) {
+		handleFilmsUnavailable(app, session)
+	} else if err != nil {
 		app.SendMessage(messages.FilmsFailure(session), keyboards.Back(session, ""))
 	} else {
 		app.SendMessage(messages.Films(session, metadata), keyboards.Films(session, metadata.CurrentPage, metadata.LastPage))
 	}
 }
 
+// handleFilmsUnavailable shows the films loaded last from the same source read-only while the Watchlist service is unavailable.
+func handleFilmsUnavailable(app models.App, session *models.Session) {
+	state := session.FilmsState
+	if len(state.Films) == 0 || state.LoadedFrom != filmsSource(session) {
+		app.SendMessage(messages.ServiceUnavailable(session), keyboards.Back(session, ""))
+		return
+	}
+
+	// Return to the page of the cached films, since pagination may have moved past it.
+	session.FilmsState.CurrentPage = state.LoadedPage
+	metadata := &filters.Metadata{
+		CurrentPage:  state.LoadedPage,
+		PageSize:     state.PageSize,
+		LastPage:     state.LastPage,
+		TotalRecords: state.TotalRecords,
+	}
+
+	app.SendMessage(messages.ServiceDegraded(session)+messages.Films(session, metadata), keyboards.FilmsReadOnly(session))
+}
+
 // HandleFilmsButtons handles button interactions related to the films list.
 // Supports actions like going back, creating new films, managing existing ones, searching, filtering, sorting, and pagination.
 func HandleFilmsButtons(app models.App, session *models.Session, back func(models.App, *models.Session)) {
@@ -177,13 +199,13 @@ func getFilms(app models.App, session *models.Session) (*filters.Metadata, error
 		return nil, err
 	}
 
-	updateSessionWithFilms(session, films, metadata)
+	updateSessionWithFilms(session, films, metadata, filmsSource(session))
 	return metadata, nil
 }
 
 // fetchFilmsFromUser retrieves films associated with the current user using the Watchlist service.
 func fetchFilmsFromUser(app models.App, session *models.Session) ([]apiModels.Film, *filters.Metadata, error) {
-	filmsResponse, err := watchlist.GetFilms(app, session)
+	filmsResponse, err := watchlist.GetClient().GetFilms(app.Context(), session)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -193,7 +215,7 @@ func fetchFilmsFromUser(app models.App, session *models.Session) ([]apiModels.Fi
 
 // fetchFilmsFromCollection retrieves films associated with the current collection using the Watchlist service.
 func fetchFilmsFromCollection(app models.App, session *models.Session) ([]apiModels.Film, *filters.Metadata, error) {
-	collectionResponse, err := watchlist.GetCollectionFilms(app, session)
+	collectionResponse, err := watchlist.GetClient().GetCollectionFilms(app.Context(), session)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -202,10 +224,26 @@ func fetchFilmsFromCollection(app models.App, session *models.Session) ([]apiMod
 	return collectionResponse.CollectionFilms.Films, &collectionResponse.Metadata, nil
 }
 
-// updateSessionWithFilms updates the session with the retrieved films and their metadata.
-func updateSessionWithFilms(session *models.Session, films []apiModels.Film, metadata *filters.Metadata) {
+// updateSessionWithFilms updates the session with the retrieved films, their metadata and source.
+// Films not loaded from the Watchlist service have an empty source and are never shown as cached data.
+func updateSessionWithFilms(session *models.Session, films []apiModels.Film, metadata *filters.Metadata, source string) {
 	session.FilmsState.Films = films
 	session.FilmsState.LastPage = metadata.LastPage
 	session.FilmsState.TotalRecords = metadata.TotalRecords
 	session.FilmsState.PageSize = metadata.PageSize
+	session.FilmsState.LoadedPage = metadata.CurrentPage
+	session.FilmsState.LoadedFrom = source
+}
+
+// filmsSource identifies the list the films in the session are loaded from:
+// the user's films or a specific collection, optionally searched by title.
+func filmsSource(session *models.Session) string {
+	source := session.Context
+	if session.Context == states.CtxCollection {
+		source = fmt.Sprintf("%s:%d", states.CtxCollection, session.CollectionDetailState.ObjectID)
+	}
+	if session.FilmsState.Title != "" {
+		source += "?" + session.FilmsState.Title
+	}
+	return source
 }
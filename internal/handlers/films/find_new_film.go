@@ -1,23 +1,27 @@
 package films
 
 import (
+	"fmt"
 	"github.com/k4sper1love/watchlist-api/pkg/filters"
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
 	"github.com/k4sper1love/watchlist-bot/internal/builders/keyboards"
 	"github.com/k4sper1love/watchlist-bot/internal/builders/messages"
 	"github.com/k4sper1love/watchlist-bot/internal/handlers/parser"
 	"github.com/k4sper1love/watchlist-bot/internal/handlers/states"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/client"
 	"github.com/k4sper1love/watchlist-bot/internal/services/parsing"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"net/http"
 	"strconv"
 	"strings"
 )
 
-// HandleFindNewFilmCommand handles the command for searching new films from Kinopoisk.
+// HandleFindNewFilmCommand handles the command for searching new films on Kinopoisk or TMDB, or anime on Shikimori or MyAnimeList.
 // Retrieves paginated films and sends a message with their details and navigation buttons.
 func HandleFindNewFilmCommand(app models.App, session *models.Session) {
-	if metadata, err := getFilmsFromKinopoisk(session); err != nil {
-		handleKinopoiskError(app, session, err)
+	if metadata, err := findNewFilms(app, session); err != nil {
+		handleFindNewFilmError(app, session, err)
 		clearStatesAndResetFilmsPage(session)
 	} else {
 		app.SendMessage(messages.FindNewFilm(session, metadata), keyboards.FindNewFilm(session, metadata.CurrentPage, metadata.LastPage))
@@ -25,7 +29,7 @@ func HandleFindNewFilmCommand(app models.App, session *models.Session) {
 }
 
 // HandleFindNewFilmButtons handles button interactions related to the search results of new films.
-// Supports actions like going back, refreshing the search, pagination, and selecting specific films.
+// Supports actions like going back, refreshing the search, pagination, search filters, and selecting specific films.
 func HandleFindNewFilmButtons(app models.App, session *models.Session) {
 	callback := utils.ParseCallback(app.Update)
 
@@ -36,9 +40,17 @@ func HandleFindNewFilmButtons(app models.App, session *models.Session) {
 
 	case states.CallFindNewFilmAgain:
 		session.ClearAllStates()
-		handleNewFilmFind(app, session)
+		if session.FilmsState.SearchAnime {
+			handleNewFilmFindAnime(app, session)
+		} else {
+			handleNewFilmFind(app, session)
+		}
 
 	default:
+		if strings.HasPrefix(callback, states.SearchFilters) {
+			handleSearchFiltersButtons(app, session, callback)
+		}
+
 		if strings.HasPrefix(callback, states.FindNewFilmPage) {
 			handleFindNewFilmPagination(app, session, callback)
 		}
@@ -87,28 +99,71 @@ func handleFindNewFilmPagination(app models.App, session *models.Session, callba
 
 // handleFindNewFilmSelect processes the selection of a film from the search results.
 // Parses the film index and navigates to the detailed view of the selected film.
+// Anime search results lack genres and descriptions, so their details are loaded by URL when available.
 func handleFindNewFilmSelect(app models.App, session *models.Session, callback string) {
-	if index, err := strconv.Atoi(strings.TrimPrefix(callback, states.SelectNewFilm)); err != nil {
+	index, err := strconv.Atoi(strings.TrimPrefix(callback, states.SelectNewFilm))
+	if err == nil && (index < 0 || index >= len(session.FilmsState.Films)) {
+		// The button may come from a stale keyboard of results that have been replaced since.
+		err = fmt.Errorf("film index %d out of range of %d found films", index, len(session.FilmsState.Films))
+	}
+	if err != nil {
 		utils.LogParseSelectError(session.TelegramID, err, callback)
 		app.SendMessage(messages.FilmsFailure(session), keyboards.Back(session, states.CallFindNewFilmBack))
-	} else {
-		session.FilmDetailState.SetFromFilm(&session.FilmsState.Films[index])
-		parser.ParseFilmImageFromURL(app, session, session.FilmDetailState.ImageURL, requestNewFilmComment)
+		return
 	}
+
+	film := &session.FilmsState.Films[index]
+	if session.FilmsState.SearchAnime {
+		if details, err := parsing.GetFilmByURL(app, session, film.URL); err == nil {
+			film = details
+		}
+	}
+
+	session.FilmDetailState.SetFromFilm(film)
+	parser.ParseFilmImageFromURL(app, session, session.FilmDetailState.ImageURL, requestNewFilmComment)
 }
 
-// getFilmsFromKinopoisk retrieves a paginated list of films from Kinopoisk using the Parsing service.
+// findNewFilms retrieves a paginated list of films using the Parsing service.
+// Films are searched on Kinopoisk, refined with the search filters, if the user has a Kinopoisk token and on TMDB otherwise.
+// Anime are searched on MyAnimeList for English users and on Shikimori, which has Russian titles, for the others.
 // Updates the session with the retrieved films and their metadata.
-func getFilmsFromKinopoisk(session *models.Session) (*filters.Metadata, error) {
-	films, metadata, err := parsing.GetFilmsFromKinopoisk(session)
+func findNewFilms(app models.App, session *models.Session) (*filters.Metadata, error) {
+	var search func(models.App, *models.Session) ([]apiModels.Film, *filters.Metadata, error)
+	switch {
+	case session.FilmsState.SearchAnime && session.Lang == "en":
+		search = parsing.GetAnimeFromMyAnimeList
+	case session.FilmsState.SearchAnime:
+		search = parsing.GetAnimeFromShikimori
+	case session.IsKinopoiskSearch():
+		search = parsing.GetFilmsFromKinopoisk
+	default:
+		search = parsing.GetFilmsFromTMDB
+	}
+
+	films, metadata, err := search(app, session)
 	if err != nil {
 		return nil, err
 	}
 
-	updateSessionWithFilms(session, films, metadata)
+	updateSessionWithFilms(session, films, metadata, "")
 	return metadata, nil
 }
 
+// handleFindNewFilmError handles errors encountered while searching new films.
+func handleFindNewFilmError(app models.App, session *models.Session, err error) {
+	if session.IsKinopoiskSearch() {
+		handleKinopoiskError(app, session, err)
+		return
+	}
+
+	switch code := client.StatusCode(err); code {
+	case http.StatusNotFound, http.StatusTooManyRequests:
+		app.SendMessage(messages.ServiceFailureCode(session, code), keyboards.Back(session, states.CallFilmsNew))
+	default:
+		app.SendMessage(messages.FilmsFailure(session), keyboards.Back(session, states.CallFilmsNew))
+	}
+}
+
 // clearStatesAndResetFilmsPage clears all session states and resets the current page of the films list.
 func clearStatesAndResetFilmsPage(session *models.Session) {
 	session.ClearAllStates()
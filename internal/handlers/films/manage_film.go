@@ -39,7 +39,7 @@ func HandleManageFilmButtons(app models.App, session *models.Session) {
 
 // handleRemoveFilmFromCollection removes the current film from its associated collection.
 func handleRemoveFilmFromCollection(app models.App, session *models.Session) {
-	if err := watchlist.DeleteCollectionFilm(app, session); err != nil {
+	if err := watchlist.GetClient().DeleteCollectionFilm(app.Context(), session); err != nil {
 		app.SendMessage(messages.RemoveFilmFailure(session), keyboards.Back(session, states.CallFilmsManage))
 		return
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/k4sper1love/watchlist-bot/internal/services/parsing"
 	"github.com/k4sper1love/watchlist-bot/internal/services/watchlist"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"net/http"
 )
 
 // HandleNewFilmCommand handles the command for creating a new film.
@@ -36,8 +37,20 @@ func HandleNewFilmButtons(app models.App, session *models.Session) {
 	case states.CallNewFilmFind:
 		handleNewFilmFind(app, session)
 
+	case states.CallNewFilmFindAnime:
+		handleNewFilmFindAnime(app, session)
+
 	case states.CallNewFilmChangeKinopoiskToken:
 		handleKinopoiskToken(app, session)
+
+	case states.CallNewFilmTestKinopoiskToken:
+		parser.TestKinopoiskToken(app, session, handleKinopoiskTokenConfirm, HandleNewFilmCommand)
+
+	case states.CallNewFilmSaveKinopoiskToken:
+		parser.SaveKinopoiskToken(app, session, HandleNewFilmCommand)
+
+	case states.CallNewFilmCancelKinopoiskToken:
+		parser.CancelKinopoiskToken(app, session, HandleNewFilmCommand)
 	}
 }
 
@@ -58,7 +71,7 @@ func HandleNewFilmProcess(app models.App, session *models.Session) {
 		parseNewFilmFromURL(app, session)
 
 	case states.AwaitNewFilmKinopoiskToken:
-		parser.ParseKinopoiskToken(app, session, HandleNewFilmCommand)
+		parser.ParseKinopoiskToken(app, session, handleKinopoiskTokenConfirm, HandleNewFilmCommand)
 
 	case states.AwaitNewFilmTitle:
 		parser.ParseFilmTitle(app, session, handleNewFilmManually, requestNewFilmYear)
@@ -101,24 +114,42 @@ func handleKinopoiskToken(app models.App, session *models.Session) {
 	session.SetState(states.AwaitNewFilmKinopoiskToken)
 }
 
+// handleKinopoiskTokenConfirm shows the masked received token and offers to test, save or discard it.
+func handleKinopoiskTokenConfirm(app models.App, session *models.Session) {
+	app.SendMessage(messages.KinopoiskTokenConfirm(session), keyboards.KinopoiskTokenConfirm(session,
+		states.CallNewFilmTestKinopoiskToken, states.CallNewFilmSaveKinopoiskToken, states.CallNewFilmCancelKinopoiskToken))
+}
+
 // handleKinopoiskError handles errors related to Kinopoisk API requests.
 // Displays appropriate error messages based on the status code.
 func handleKinopoiskError(app models.App, session *models.Session, err error) {
-	code := client.ParseErrorStatusCode(err)
-	if code == 401 || code == 403 {
+	switch code := client.StatusCode(err); code {
+	case http.StatusUnauthorized, http.StatusForbidden:
 		app.SendMessage(messages.KinopoiskFailureCode(session, code), keyboards.NewFilmChangeToken(session))
-	} else {
+	case http.StatusNotFound, http.StatusTooManyRequests:
+		app.SendMessage(messages.KinopoiskFailureCode(session, code), keyboards.Back(session, states.CallFilmsNew))
+	default:
 		app.SendMessage(messages.FilmsFailure(session), keyboards.Back(session, states.CallFilmsNew))
 	}
 }
 
-// handleNewFilmFind prompts the user to search for a new film by title using Kinopoisk.
+// handleNewFilmFind prompts the user to search for a new film by title using Kinopoisk or TMDB.
+// A Kinopoisk token is requested only if TMDB is not configured. Kinopoisk searches can be refined before entering the title.
 func handleNewFilmFind(app models.App, session *models.Session) {
-	if session.KinopoiskAPIToken == "" {
+	if session.KinopoiskAPIToken == "" && app.Config.TMDBAPIToken == "" {
 		handleKinopoiskToken(app, session)
 		return
 	}
 
+	session.FilmsState.SearchAnime = false
+	session.FilmsState.Title = ""
+	app.SendMessage(messages.RequestFilmTitle(session), keyboards.NewFilmFind(session))
+	session.SetState(states.AwaitNewFilmFind)
+}
+
+// handleNewFilmFindAnime prompts the user to search for a new anime by title using Shikimori or MyAnimeList.
+func handleNewFilmFindAnime(app models.App, session *models.Session) {
+	session.FilmsState.SearchAnime = true
 	app.SendMessage(messages.RequestFilmTitle(session), keyboards.Cancel(session))
 	session.SetState(states.AwaitNewFilmFind)
 }
@@ -130,9 +161,11 @@ func handleNewFilmFromURL(app models.App, session *models.Session) {
 }
 
 // parseNewFilmFromURL processes the URL provided by the user to create a new film.
+// Films from sites without a dedicated parser may be incomplete, so they are reviewed step by step before saving.
 func parseNewFilmFromURL(app models.App, session *models.Session) {
 	url := utils.ParseMessageString(app.Update)
 	isKinopoisk := parsing.IsKinopoisk(url)
+	isSupported := parsing.IsSupported(url)
 
 	if isKinopoisk && session.KinopoiskAPIToken == "" {
 		handleKinopoiskToken(app, session)
@@ -145,9 +178,19 @@ func parseNewFilmFromURL(app models.App, session *models.Session) {
 		return
 	}
 
-	film.URL = url
 	session.FilmDetailState.SetFromFilm(film)
-	parser.ParseFilmImageFromURL(app, session, film.ImageURL, requestNewFilmComment)
+	if isSupported {
+		parser.ParseFilmImageFromURL(app, session, film.ImageURL, requestNewFilmComment)
+	} else {
+		parser.ParseFilmImageFromPublicURL(app, session, film.ImageURL, checkNewFilmFromPage)
+	}
+}
+
+// checkNewFilmFromPage shows the film found on a page of an arbitrary site and starts the manual flow,
+// in which skipping a step keeps the found value.
+func checkNewFilmFromPage(app models.App, session *models.Session) {
+	app.SendMessage(messages.NewFilmFromPage(session), nil)
+	handleNewFilmManually(app, session)
 }
 
 // handleNewFilmFromURLError handles errors encountered while processing a film from a URL.
@@ -159,13 +202,24 @@ func handleNewFilmFromURLError(app models.App, session *models.Session, err erro
 		return
 	}
 
-	app.SendMessage(messages.FilmsFailure(session), nil)
+	switch code := client.StatusCode(err); code {
+	case http.StatusNotFound, http.StatusTooManyRequests:
+		app.SendMessage(messages.ServiceFailureCode(session, code), nil)
+	default:
+		app.SendMessage(messages.FilmsFailure(session), nil)
+	}
 	HandleNewFilmCommand(app, session)
 }
 
 // handleNewFilmManually prompts the user to create a new film by entering details manually.
+// The title can be skipped only if it is already filled in, such as for a film found on a page.
 func handleNewFilmManually(app models.App, session *models.Session) {
-	app.SendMessage(messages.RequestFilmTitle(session), keyboards.Cancel(session))
+	keyboard := keyboards.Cancel(session)
+	if session.FilmDetailState.Title != "" {
+		keyboard = keyboards.SkipAndCancel(session)
+	}
+
+	app.SendMessage(messages.RequestFilmTitle(session), keyboard)
 	session.SetState(states.AwaitNewFilmTitle)
 }
 
@@ -257,7 +311,7 @@ func createNewFilm(app models.App, session *models.Session) (*apiModels.Film, er
 
 // createNewUserFilm creates a new film associated with the current user using the Watchlist service.
 func createNewUserFilm(app models.App, session *models.Session) (*apiModels.Film, error) {
-	film, err := watchlist.CreateFilm(app, session)
+	film, err := watchlist.GetClient().CreateFilm(app.Context(), session)
 	if err != nil {
 		return nil, err
 	}
@@ -268,7 +322,7 @@ func createNewUserFilm(app models.App, session *models.Session) (*apiModels.Film
 
 // createNewCollectionFilm creates a new film and associates it with a collection using the Watchlist service.
 func createNewCollectionFilm(app models.App, session *models.Session) (*apiModels.Film, error) {
-	collectionFilm, err := watchlist.CreateCollectionFilm(app, session)
+	collectionFilm, err := watchlist.GetClient().CreateCollectionFilm(app.Context(), session)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,38 @@
+package films
+
+import (
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/router"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/states"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+)
+
+// Routes returns the routes handled by the films package.
+// The films list buttons depend on the caller's navigation context and are registered by the handlers package.
+func Routes() []router.Route {
+	return []router.Route{
+		{Commands: []string{"films"}, Callbacks: []string{states.CallMenuFilms}, Handler: handleFilmsMenu},
+		{Callbacks: []string{states.FilmFilters}, Handler: HandleFilmFiltersButtons},
+		{Callbacks: []string{states.FilmSorting}, Handler: HandleSortingFilmsButtons},
+		{Callbacks: []string{states.FindFilms}, Handler: HandleFindFilmsButtons},
+		{Callbacks: []string{states.FindNewFilm, states.SelectNewFilm}, Writes: true, Handler: HandleFindNewFilmButtons},
+		{Callbacks: []string{states.NewFilm}, Writes: true, Handler: HandleNewFilmButtons},
+		{Callbacks: []string{states.ManageFilm}, Writes: true, Handler: HandleManageFilmButtons},
+		{Callbacks: []string{states.UpdateFilm}, Writes: true, Handler: HandleUpdateFilmButtons},
+		{Callbacks: []string{states.FilmDetail}, Handler: HandleFilmDetailButtons},
+		{States: []string{states.FilmFiltersAwait}, Handler: HandleFilmFiltersProcess},
+		{States: []string{states.FilmSortingAwait}, Handler: HandleSortingFilmsProcess},
+		{States: []string{states.SearchFiltersAwait}, Handler: HandleSearchFiltersProcess},
+		{States: []string{states.FilmsAwait}, Handler: HandleFilmsProcess},
+		{States: []string{states.NewFilmAwait}, Writes: true, Handler: HandleNewFilmProcess},
+		{States: []string{states.UpdateFilmAwait}, Writes: true, Handler: HandleUpdateFilmProcess},
+		{States: []string{states.ViewedFilmAwait}, Writes: true, Handler: HandleViewedFilmProcess},
+		{States: []string{states.DeleteFilmAwait}, Writes: true, Handler: HandleDeleteFilmProcess},
+	}
+}
+
+// handleFilmsMenu opens the user's films list from the first page.
+func handleFilmsMenu(app models.App, session *models.Session) {
+	session.FilmsState.CurrentPage = 1
+	session.SetContext(states.CtxFilm)
+	HandleFilmsCommand(app, session)
+}
@@ -0,0 +1,154 @@
+package films
+
+import (
+	"github.com/k4sper1love/watchlist-bot/internal/builders/keyboards"
+	"github.com/k4sper1love/watchlist-bot/internal/builders/messages"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/states"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/validator"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"strings"
+)
+
+// maxSearchFilterLength is the maximum length of a genre or country entered as a search filter.
+const maxSearchFilterLength = 50
+
+// HandleSearchFiltersCommand handles the command for refining the search for new films on Kinopoisk.
+// Sends a message with options to select and configure search filters.
+func HandleSearchFiltersCommand(app models.App, session *models.Session) {
+	app.SendMessage(messages.SearchFilters(session), keyboards.SearchFilters(session))
+}
+
+// HandleSearchFiltersProcess processes the workflow for configuring a search filter.
+// Handles the states awaiting the value of a search filter.
+func HandleSearchFiltersProcess(app models.App, session *models.Session) {
+	if utils.IsCancel(app.Update) {
+		resetStateAndHandleSearchFilters(app, session)
+		return
+	}
+
+	field := strings.TrimPrefix(session.State, states.SearchFiltersAwait)
+	if utils.IsReset(app.Update) {
+		handleSearchFilterReset(app, session, field)
+		return
+	}
+
+	parseSearchFilter(app, session, field)
+}
+
+// handleSearchFiltersButtons handles button interactions related to search filters.
+// Supports actions like searching with the filters, resetting them, removing a filter from the search results,
+// and selecting specific filters.
+func handleSearchFiltersButtons(app models.App, session *models.Session, callback string) {
+	switch {
+	case callback == states.CallSearchFilters:
+		resetStateAndHandleSearchFilters(app, session)
+
+	case callback == states.CallSearchFiltersSearch:
+		refreshNewFilmSearch(app, session)
+
+	case callback == states.CallSearchFiltersAllReset:
+		session.FilmsState.SearchFilters.ResetAll()
+		app.SendMessage(messages.ResetFiltersSuccess(session), nil)
+		resetStateAndHandleSearchFilters(app, session)
+
+	case strings.HasPrefix(callback, states.SearchFiltersRemove):
+		field := strings.TrimPrefix(callback, states.SearchFiltersRemove)
+		session.FilmsState.SearchFilters.Reset(field)
+		app.SendMessage(messages.ResetFilterSuccess(session, field), nil)
+		refreshNewFilmSearch(app, session)
+
+	case strings.HasPrefix(callback, states.SearchFiltersSelect):
+		handleSearchFilterSelect(app, session, strings.TrimPrefix(callback, states.SearchFiltersSelect))
+
+	case strings.HasPrefix(callback, states.SearchFiltersType):
+		applySearchFilter(app, session, "filmType", strings.TrimPrefix(callback, states.SearchFiltersType))
+	}
+}
+
+// handleSearchFilterSelect prompts the user to enter the value of a search filter or, for the type of films, to choose it.
+func handleSearchFilterSelect(app models.App, session *models.Session, field string) {
+	keyboard := keyboards.SearchFilterInput(session, field)
+	if field == "filmType" {
+		keyboard = keyboards.SearchFilterType(session)
+	}
+
+	app.SendMessage(messages.RequestSearchFilter(session, field), keyboard)
+	session.SetState(states.SearchFiltersAwait + field)
+}
+
+// parseSearchFilter processes the user's input for a search filter.
+// Years and ratings are validated as ranges; genres are lowercased, as Kinopoisk names them in lowercase.
+func parseSearchFilter(app models.App, session *models.Session, field string) {
+	input := utils.ParseMessageString(app.Update)
+
+	switch field {
+	case "year", "minRating":
+		config := getSearchFilterRangeConfig(field)
+		value, err := utils.ValidateFiltersRange(input, config)
+		if err != nil {
+			app.SendMessage(messages.InvalidFilterRange(session, config), nil)
+			handleSearchFilterSelect(app, session, field)
+			return
+		}
+		input = value
+
+	case "genre", "country":
+		if !utils.IsValidStringLength(input, 1, maxSearchFilterLength) {
+			validator.HandleInvalidInputLength(app, session, 1, maxSearchFilterLength)
+			handleSearchFilterSelect(app, session, field)
+			return
+		}
+		if field == "genre" {
+			input = strings.ToLower(input)
+		}
+
+	default:
+		// The type of films is only chosen with buttons.
+		handleSearchFilterSelect(app, session, field)
+		return
+	}
+
+	applySearchFilter(app, session, field, input)
+}
+
+// getSearchFilterRangeConfig retrieves the configuration for a range-based search filter based on its field.
+func getSearchFilterRangeConfig(field string) utils.FilterRangeConfig {
+	if field == "year" {
+		return getFilterRangeConfig("year")
+	}
+	return getFilterRangeConfig("rating")
+}
+
+// applySearchFilter applies the value of a search filter and reloads the search filters menu.
+func applySearchFilter(app models.App, session *models.Session, field, value string) {
+	session.FilmsState.SearchFilters.Apply(field, value)
+	app.SendMessage(messages.FilterApplied(session, field, "⚙️"), nil)
+	resetStateAndHandleSearchFilters(app, session)
+}
+
+// handleSearchFilterReset resets a specific search filter and reloads the search filters menu.
+func handleSearchFilterReset(app models.App, session *models.Session, field string) {
+	session.FilmsState.SearchFilters.Reset(field)
+	app.SendMessage(messages.ResetFilterSuccess(session, field), nil)
+	resetStateAndHandleSearchFilters(app, session)
+}
+
+// resetStateAndHandleSearchFilters clears the session state and reloads the search filters menu.
+func resetStateAndHandleSearchFilters(app models.App, session *models.Session) {
+	session.ClearState()
+	HandleSearchFiltersCommand(app, session)
+}
+
+// refreshNewFilmSearch repeats the search for new films with the current search filters from the first page,
+// or prompts the user to enter a title if nothing has been searched yet.
+func refreshNewFilmSearch(app models.App, session *models.Session) {
+	session.ClearState()
+	if session.FilmsState.Title == "" {
+		handleNewFilmFind(app, session)
+		return
+	}
+
+	session.FilmsState.CurrentPage = 1
+	HandleFindNewFilmCommand(app, session)
+}
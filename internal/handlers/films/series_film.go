@@ -0,0 +1,47 @@
+package films
+
+import (
+	"github.com/k4sper1love/watchlist-bot/internal/builders/messages"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/parsing"
+)
+
+// HandleNextEpisodeCommand handles the command for marking the next episode of a series as watched.
+// After the last episode, the series is marked as viewed as well and the user is offered to rate it.
+func HandleNextEpisodeCommand(app models.App, session *models.Session) {
+	description := session.FilmDetailState.Film.Description
+	series, _ := parsing.ParseSeries(description)
+	series.NextEpisode()
+	session.FilmDetailState.Description = series.SetDescription(session.Lang, description)
+
+	if series.IsFinished() {
+		session.FilmDetailState.SetViewed(true)
+		HandleUpdateFilm(app, session, finishSeries)
+		return
+	}
+	HandleUpdateFilm(app, session, HandleFilmDetailCommand)
+}
+
+// finishSeries notifies the user that the last episode of the viewed series has been watched and offers to rate it.
+// If the series could not be saved as viewed, the detailed view is shown instead.
+func finishSeries(app models.App, session *models.Session) {
+	if !session.FilmDetailState.Film.IsViewed {
+		HandleFilmDetailCommand(app, session)
+		return
+	}
+
+	app.SendMessage(messages.SeriesFinished(session), nil)
+	HandleViewedFilmCommand(app, session)
+}
+
+// finishUpdateSeriesProcess finalizes the update of the seasons or the last watched episode of a series.
+// If the last episode has been watched, the series is marked as viewed as well.
+func finishUpdateSeriesProcess(app models.App, session *models.Session) {
+	series, _ := parsing.ParseSeries(session.FilmDetailState.Description)
+	if series.IsFinished() && !session.FilmDetailState.Film.IsViewed {
+		session.FilmDetailState.SetViewed(true)
+		app.SendMessage(messages.SeriesFinished(session), nil)
+	}
+
+	finishUpdateFilmProcess(app, session)
+}
@@ -59,6 +59,12 @@ func HandleUpdateFilmButtons(app models.App, session *models.Session) {
 
 	case states.CallUpdateFilmReview:
 		handleUpdateFilmReview(app, session)
+
+	case states.CallUpdateFilmSeasons:
+		handleUpdateFilmSeasons(app, session)
+
+	case states.CallUpdateFilmWatched:
+		handleUpdateFilmWatched(app, session)
 	}
 }
 
@@ -104,6 +110,12 @@ func HandleUpdateFilmProcess(app models.App, session *models.Session) {
 
 	case states.AwaitUpdateFilmReview:
 		parser.ParseFilmReview(app, session, handleUpdateFilmReview, finishUpdateFilmProcess)
+
+	case states.AwaitUpdateFilmSeasons:
+		parser.ParseFilmSeasons(app, session, handleUpdateFilmSeasons, finishUpdateSeriesProcess)
+
+	case states.AwaitUpdateFilmWatched:
+		parser.ParseFilmWatchedEpisode(app, session, handleUpdateFilmWatched, finishUpdateSeriesProcess)
 	}
 }
 
@@ -179,12 +191,26 @@ func handleUpdateFilmReview(app models.App, session *models.Session) {
 	session.SetState(states.AwaitUpdateFilmReview)
 }
 
+// handleUpdateFilmSeasons prompts the user to enter the numbers of episodes in the seasons of the series.
+func handleUpdateFilmSeasons(app models.App, session *models.Session) {
+	app.SendMessage(messages.RequestFilmSeasons(session), keyboards.Cancel(session))
+	session.SetState(states.AwaitUpdateFilmSeasons)
+}
+
+// handleUpdateFilmWatched prompts the user to enter the last watched episode of the series.
+func handleUpdateFilmWatched(app models.App, session *models.Session) {
+	app.SendMessage(messages.RequestFilmWatchedEpisode(session), keyboards.Cancel(session))
+	session.SetState(states.AwaitUpdateFilmWatched)
+}
+
 // HandleUpdateFilm updates the film using the Watchlist service and resets the session state.
 // Sends success or failure messages based on the result of the update operation.
 func HandleUpdateFilm(app models.App, session *models.Session, backFunc func(models.App, *models.Session)) {
 	session.FilmDetailState.SyncValues()
 
-	if err := updateFilmAndState(app, session); err != nil {
+	if err := updateFilmAndState(app, session); watchlist.IsUnavailable(err) {
+		app.SendMessage(messages.ReadOnlyMode(session), nil)
+	} else if err != nil {
 		app.SendMessage(messages.UpdateFilmFailure(session), nil)
 	} else {
 		app.SendMessage(messages.UpdateFilmSuccess(session), nil)
@@ -196,7 +222,7 @@ func HandleUpdateFilm(app models.App, session *models.Session, backFunc func(mod
 
 // updateFilmAndState updates the film in the database and synchronizes the session state with the updated data.
 func updateFilmAndState(app models.App, session *models.Session) error {
-	film, err := watchlist.UpdateFilm(app, session)
+	film, err := watchlist.GetClient().UpdateFilm(app.Context(), session)
 	if err != nil {
 		return err
 	}
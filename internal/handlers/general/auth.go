@@ -34,7 +34,7 @@ func handleLogoutConfirm(app models.App, session *models.Session) {
 		return
 	}
 
-	if err := watchlist.Logout(app, session); err != nil {
+	if err := watchlist.GetClient().Logout(app.Context(), session); err != nil {
 		app.SendMessage(messages.LogoutFailure(session), keyboards.Back(session, ""))
 		session.ClearState()
 		return
@@ -17,11 +17,19 @@ func Auth(app models.App, session *models.Session) bool {
 	if IsBanned(app, session) {
 		return false
 	}
-	if isAuthenticated(app, session) || attemptLoginOrRegister(app, session) == nil {
+	if isAuthenticated(session) {
 		return true
 	}
 
-	app.SendMessage(messages.AuthFailure(session), nil)
+	err := attemptLoginOrRegister(app, session)
+	switch {
+	case err == nil:
+		return true
+	case watchlist.IsUnavailable(err):
+		app.SendMessage(messages.ServiceUnavailable(session), nil)
+	default:
+		app.SendMessage(messages.AuthFailure(session), nil)
+	}
 	session.ClearAllStates()
 	return false
 }
@@ -55,20 +63,19 @@ func IsBanned(app models.App, session *models.Session) bool {
 }
 
 // isAuthenticated checks if the user is already authenticated.
-// Validates the access token or refreshes it using the refresh token if necessary.
-func isAuthenticated(app models.App, session *models.Session) bool {
-	return session.AccessToken != "" &&
-		(watchlist.IsTokenValid(app, session, session.AccessToken) ||
-			(session.RefreshToken != "" && watchlist.RefreshAccessToken(app, session) == nil))
+// The tokens are not validated here: the watchlist service renews them when the API rejects them.
+func isAuthenticated(session *models.Session) bool {
+	return session.AccessToken != "" && session.RefreshToken != ""
 }
 
 // attemptLoginOrRegister attempts to log in or register the user.
+// Registration is not attempted while the Watchlist service is unavailable.
 func attemptLoginOrRegister(app models.App, session *models.Session) error {
-	if err := watchlist.Login(app, session); err == nil {
-		return nil
+	if err := watchlist.GetClient().Login(app.Context(), session); err == nil || watchlist.IsUnavailable(err) {
+		return err
 	}
 
-	if err := watchlist.Register(app, session); err != nil {
+	if err := watchlist.GetClient().Register(app.Context(), session); err != nil {
 		slog.Error("failed to login/register", slog.Any("error", err), slog.Int("telegram_id", session.TelegramID))
 		return err
 	}
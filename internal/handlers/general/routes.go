@@ -0,0 +1,24 @@
+package general
+
+import (
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/router"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/states"
+)
+
+// Routes returns the routes handled by the general package.
+func Routes() []router.Route {
+	return []router.Route{
+		{Commands: []string{"start"}, Handler: HandleStartCommand},
+		{Commands: []string{"help"}, Handler: HandleHelpCommand},
+		{Commands: []string{"menu"}, Callbacks: []string{states.CallMainMenu}, Handler: HandleMenuCommand},
+		{Commands: []string{"logout"}, Callbacks: []string{states.CallMenuLogout}, Handler: HandleLogoutCommand},
+		{Commands: []string{"settings"}, Callbacks: []string{states.CallMenuSettings}, Handler: HandleSettingsCommand},
+		{Commands: []string{"feedback"}, Callbacks: []string{states.CallMenuFeedback}, Handler: HandleFeedbackCommand},
+		{Callbacks: []string{states.SelectStartLang}, Handler: HandleLanguageButton},
+		{Callbacks: []string{states.Settings, states.SelectLang}, Handler: HandleSettingsButtons},
+		{Callbacks: []string{states.FeedbackCategory}, Handler: HandleFeedbackButtons},
+		{States: []string{states.LogoutAwait}, Writes: true, Handler: HandleLogoutProcess},
+		{States: []string{states.FeedbackAwait}, Handler: HandleFeedbackProcess},
+		{States: []string{states.SettingsAwait}, Handler: HandleSettingsProcess},
+	}
+}
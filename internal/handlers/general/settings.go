@@ -29,6 +29,15 @@ func HandleSettingsButtons(app models.App, session *models.Session) {
 	case states.CallSettingsKinopoiskToken:
 		requestKinopoiskToken(app, session)
 
+	case states.CallSettingsTestKinopoiskToken:
+		parser.TestKinopoiskToken(app, session, requestKinopoiskTokenConfirm, HandleSettingsCommand)
+
+	case states.CallSettingsSaveKinopoiskToken:
+		parser.SaveKinopoiskToken(app, session, HandleSettingsCommand)
+
+	case states.CallSettingsCancelKinopoiskToken:
+		parser.CancelKinopoiskToken(app, session, HandleSettingsCommand)
+
 	case states.CallSettingsFilmsPageSize:
 		requestSettingsFilmsPageSize(app, session)
 
@@ -55,7 +64,7 @@ func HandleSettingsProcess(app models.App, session *models.Session) {
 
 	switch session.State {
 	case states.AwaitSettingsKinopoiskToken:
-		parser.ParseKinopoiskToken(app, session, HandleSettingsCommand)
+		parser.ParseKinopoiskToken(app, session, requestKinopoiskTokenConfirm, HandleSettingsCommand)
 
 	case states.AwaitSettingsFilmsPageSize:
 		parser.ParseSettingsFilmsPageSize(app, session, requestSettingsFilmsPageSize, finishUpdatePageSize)
@@ -91,6 +100,12 @@ func requestKinopoiskToken(app models.App, session *models.Session) {
 	session.SetState(states.AwaitSettingsKinopoiskToken)
 }
 
+// requestKinopoiskTokenConfirm shows the masked received token and offers to test, save or discard it.
+func requestKinopoiskTokenConfirm(app models.App, session *models.Session) {
+	app.SendMessage(messages.KinopoiskTokenConfirm(session), keyboards.KinopoiskTokenConfirm(session,
+		states.CallSettingsTestKinopoiskToken, states.CallSettingsSaveKinopoiskToken, states.CallSettingsCancelKinopoiskToken))
+}
+
 // requestSettingsFilmsPageSize prompts the user to update the page size for films.
 func requestSettingsFilmsPageSize(app models.App, session *models.Session) {
 	app.SendMessage(messages.SettingsPageSize(session, session.FilmsState.PageSize), keyboards.Cancel(session))
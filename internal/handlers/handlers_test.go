@@ -0,0 +1,385 @@
+package handlers_test
+
+import (
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/states"
+	"github.com/k4sper1love/watchlist-bot/internal/harness"
+	"github.com/k4sper1love/watchlist-bot/internal/messenger"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/pkg/translator"
+	"testing"
+)
+
+// startUser registers a user through /start and the English language button.
+func startUser(t *testing.T, h *harness.Harness, telegramID int) *harness.User {
+	t.Helper()
+
+	user := h.User(telegramID)
+	user.Send("/start")
+	user.Click(states.SelectStartLang + "en")
+	return user
+}
+
+// expectText fails the test if none of the replies contains the translation of the key.
+func expectText(t *testing.T, replies []messenger.Record, key string) {
+	t.Helper()
+
+	if text := translator.Translate("en", key, nil, nil); !harness.HasText(replies, text) {
+		t.Fatalf("expected a reply containing %q, got %+v", text, replies)
+	}
+}
+
+// expectCallback fails the test if none of the replies has a button with the callback data.
+func expectCallback(t *testing.T, replies []messenger.Record, data string) {
+	t.Helper()
+
+	if !harness.HasCallback(replies, data) {
+		t.Fatalf("expected a button with callback %q, got %+v", data, replies)
+	}
+}
+
+func TestStartRegistersUserAndOffersLanguages(t *testing.T) {
+	h := harness.New(t, harness.Options{})
+	user := h.User(1001)
+
+	replies := user.Send("/start")
+
+	expectCallback(t, replies, states.SelectStartLang+"en")
+	expectCallback(t, replies, states.SelectStartLang+"ru")
+
+	session := user.Session()
+	if session.User.Username != "user_1001" {
+		t.Fatalf("expected registered user user_1001, got %q", session.User.Username)
+	}
+	if session.AccessToken == "" || session.RefreshToken == "" {
+		t.Fatal("expected tokens to be saved after registration")
+	}
+}
+
+func TestLanguageButtonShowsMenu(t *testing.T) {
+	h := harness.New(t, harness.Options{})
+	user := h.User(1001)
+	user.Send("/start")
+
+	replies := user.Click(states.SelectStartLang + "ru")
+
+	if got := user.Session().Lang; got != "ru" {
+		t.Fatalf("expected language ru, got %q", got)
+	}
+	if text := translator.Translate("ru", "mainMenu", nil, nil); !harness.HasText(replies, text) {
+		t.Fatalf("expected the main menu in Russian, got %+v", replies)
+	}
+	for _, data := range []string{states.CallMenuFilms, states.CallMenuCollections, states.CallMenuProfile, states.CallMenuSettings} {
+		expectCallback(t, replies, data)
+	}
+	if harness.HasCallback(replies, states.CallMenuAdmin) {
+		t.Fatal("expected no admin panel for a regular user")
+	}
+}
+
+func TestMenuShowsAdminPanelToRoot(t *testing.T) {
+	h := harness.New(t, harness.Options{RootID: 1001})
+	user := startUser(t, h, 1001)
+
+	replies := user.Send("/menu")
+
+	expectCallback(t, replies, states.CallMenuAdmin)
+}
+
+func TestMenuSections(t *testing.T) {
+	h := harness.New(t, harness.Options{})
+	user := startUser(t, h, 1001)
+
+	tests := []struct {
+		name     string
+		callback string
+		key      string
+	}{
+		{"settings", states.CallMenuSettings, "settingsChoice"},
+		{"feedback", states.CallMenuFeedback, "feedbackCategoryChoice"},
+		{"profile", states.CallMenuProfile, "profile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replies := user.Click(tt.callback)
+
+			expectText(t, replies, tt.key)
+			expectCallback(t, replies, states.CallMainMenu)
+		})
+	}
+}
+
+func TestFilmsAndCollectionsMenus(t *testing.T) {
+	h := harness.New(t, harness.Options{})
+	user := startUser(t, h, 1001)
+
+	if replies := user.Click(states.CallMenuFilms); len(replies) == 0 {
+		t.Fatal("expected a reply to the films menu")
+	}
+	session := user.Session()
+	if session.Context != states.CtxFilm || session.FilmsState.CurrentPage != 1 {
+		t.Fatalf("expected film context on the first page, got %q and page %d", session.Context, session.FilmsState.CurrentPage)
+	}
+
+	if replies := user.Click(states.CallMenuCollections); len(replies) == 0 {
+		t.Fatal("expected a reply to the collections menu")
+	}
+	if page := user.Session().CollectionsState.CurrentPage; page != 1 {
+		t.Fatalf("expected collections on the first page, got %d", page)
+	}
+}
+
+func TestSettingsLanguage(t *testing.T) {
+	h := harness.New(t, harness.Options{})
+	user := startUser(t, h, 1001)
+
+	user.Send("/settings")
+	replies := user.Click(states.CallSettingsLanguage)
+	expectCallback(t, replies, states.SelectLang+"kk")
+
+	replies = user.Click(states.SelectLang + "kk")
+
+	if got := user.Session().Lang; got != "kk" {
+		t.Fatalf("expected language kk, got %q", got)
+	}
+	if text := translator.Translate("kk", "settingsChoice", nil, nil); !harness.HasText(replies, text) {
+		t.Fatalf("expected to return to the settings in Kazakh, got %+v", replies)
+	}
+}
+
+func TestFeedbackIsSaved(t *testing.T) {
+	h := harness.New(t, harness.Options{})
+	user := startUser(t, h, 1001)
+
+	user.Send("/feedback")
+	replies := user.Click(states.CallFeedbackCategoryBugs)
+	expectCallback(t, replies, states.CallProcessCancel)
+
+	if state := user.Session().State; state != states.AwaitFeedbackMessage {
+		t.Fatalf("expected to await the feedback message, got state %q", state)
+	}
+
+	replies = user.Send("The search button does nothing")
+
+	expectText(t, replies, "feedbackSuccess")
+	if state := user.Session().State; state != "" {
+		t.Fatalf("expected the state to be cleared, got %q", state)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	h := harness.New(t, harness.Options{})
+	user := startUser(t, h, 1001)
+
+	replies := user.Send("/logout")
+	expectCallback(t, replies, states.CallYes)
+	expectCallback(t, replies, states.CallNo)
+
+	user.Click(states.CallYes)
+
+	session := user.Session()
+	if session.AccessToken != "" || session.RefreshToken != "" {
+		t.Fatal("expected tokens to be cleared after logout")
+	}
+}
+
+func TestLogoutCancelled(t *testing.T) {
+	h := harness.New(t, harness.Options{})
+	user := startUser(t, h, 1001)
+
+	user.Send("/logout")
+	replies := user.Click(states.CallNo)
+
+	expectText(t, replies, "cancelAction")
+	expectText(t, replies, "mainMenu")
+	if session := user.Session(); session.State != "" || session.AccessToken == "" {
+		t.Fatalf("expected an idle authenticated session, got state %q", session.State)
+	}
+}
+
+func TestResetClearsState(t *testing.T) {
+	h := harness.New(t, harness.Options{})
+	user := startUser(t, h, 1001)
+
+	user.Send("/feedback")
+	user.Click(states.CallFeedbackCategoryIssues)
+
+	user.Send("/reset")
+
+	if state := user.Session().State; state != "" {
+		t.Fatalf("expected the state to be reset, got %q", state)
+	}
+}
+
+func TestProfileWhileAPIUnavailable(t *testing.T) {
+	h := harness.New(t, harness.Options{Config: func(config *models.Config) {
+		config.WatchlistBreakerThreshold = 1
+	}})
+	user := startUser(t, h, 1001)
+
+	h.API.API.SetAvailable(false)
+	user.Click(states.CallMenuProfile)
+	replies := user.Click(states.CallMenuProfile)
+
+	expectText(t, replies, "profile")
+	expectCallback(t, replies, states.CallMainMenu)
+}
+
+// addFilmManually creates a film through the manual new-film wizard, skipping the optional steps.
+func addFilmManually(t *testing.T, user *harness.User, title, year string) {
+	t.Helper()
+
+	user.Click(states.CallMenuFilms)
+	user.Click(states.CallFilmsNew)
+	expectText(t, user.Click(states.CallNewFilmManually), "filmRequestTitle")
+
+	user.Send(title)
+	user.Send(year)
+	for _, step := range []string{"genre", "description", "rating", "image", "url", "comment"} {
+		if replies := user.Click(states.CallProcessSkip); len(replies) == 0 {
+			t.Fatalf("expected a reply after skipping the %s", step)
+		}
+	}
+	expectText(t, user.Click(states.CallNo), "createFilmSuccess")
+}
+
+func TestNewFilmManually(t *testing.T) {
+	h := harness.New(t, harness.Options{})
+	user := startUser(t, h, 1001)
+
+	user.Click(states.CallMenuFilms)
+	user.Click(states.CallFilmsNew)
+	user.Click(states.CallNewFilmManually)
+
+	user.Send("The Matrix")
+	user.Send("1999")
+	user.Send("Sci-Fi")
+	user.Click(states.CallProcessSkip)
+	user.Send("8.7")
+	user.Click(states.CallProcessSkip)
+	user.Send("https://example.com/the-matrix")
+	user.Send("Rewatch with friends")
+	user.Click(states.CallYes)
+	user.Send("9")
+	replies := user.Send("Still holds up")
+
+	expectText(t, replies, "createFilmSuccess")
+	if state := user.Session().State; state != "" {
+		t.Fatalf("expected the state to be cleared, got %q", state)
+	}
+
+	films := h.API.API.Films(1001)
+	if len(films) != 1 {
+		t.Fatalf("expected one stored film, got %d", len(films))
+	}
+	film := films[0]
+	if film.Title != "The Matrix" || film.Year != 1999 || film.Genre != "Sci-Fi" || film.Rating != 8.7 ||
+		film.URL != "https://example.com/the-matrix" || film.Comment != "Rewatch with friends" {
+		t.Fatalf("expected the entered details to be stored, got %+v", film)
+	}
+	if !film.IsViewed || film.UserRating != 9 || film.Review != "Still holds up" {
+		t.Fatalf("expected the film to be stored as viewed with the rating and review, got %+v", film)
+	}
+	if got := user.Session().FilmDetailState.Film.ID; got != film.ID {
+		t.Fatalf("expected the detail view of film %d, got %d", film.ID, got)
+	}
+}
+
+func TestFilmFilterYearRange(t *testing.T) {
+	h := harness.New(t, harness.Options{})
+	user := startUser(t, h, 1001)
+	addFilmManually(t, user, "The Matrix", "1999")
+	addFilmManually(t, user, "Inception", "2010")
+
+	user.Click(states.CallMenuFilms)
+	user.Click(states.CallFilmsFilters)
+	user.Click(states.CallFilmFiltersSelectRangeYear)
+
+	replies := user.Send("1880-1990")
+	expectText(t, replies, "invalidInput")
+	if state := user.Session().State; state != states.FilmFiltersAwaitRange+"year" {
+		t.Fatalf("expected to await the year range again, got state %q", state)
+	}
+
+	replies = user.Send("2000-2020")
+	expectCallback(t, replies, states.CallFilmFiltersBack)
+	if got := user.Session().FilmsState.FilmFilters.Year; got != "2000-2020" {
+		t.Fatalf("expected the year filter 2000-2020, got %q", got)
+	}
+
+	user.Click(states.CallFilmFiltersBack)
+	films := user.Session().FilmsState.Films
+	if len(films) != 1 || films[0].Title != "Inception" {
+		t.Fatalf("expected only the film from 2010, got %+v", films)
+	}
+}
+
+func TestFindNewFilmStaleSelection(t *testing.T) {
+	h := harness.New(t, harness.Options{})
+	user := startUser(t, h, 1001)
+
+	replies := user.Click(states.SelectNewFilm + "3")
+
+	expectText(t, replies, "getFilmsFailure")
+	expectCallback(t, replies, states.CallFindNewFilmBack)
+}
+
+func TestAdminBroadcast(t *testing.T) {
+	h := harness.New(t, harness.Options{RootID: 1001})
+	admin := startUser(t, h, 1001)
+	users := []*harness.User{admin, startUser(t, h, 1002), startUser(t, h, 1003)}
+	text := "Maintenance tonight at 23:00"
+
+	admin.Click(states.CallMenuAdmin)
+	admin.Click(states.CallAdminBroadcast)
+	admin.Click(states.CallProcessSkip)
+	admin.Send(text)
+	replies := admin.Click(states.CallYes)
+
+	expectText(t, replies, "preview")
+	if !harness.HasText(replies, text) {
+		t.Fatalf("expected a preview of the broadcast, got %+v", replies)
+	}
+	expectCallback(t, replies, states.CallBroadcastSend)
+
+	admin.Click(states.CallBroadcastSend)
+
+	for _, user := range users {
+		var delivered bool
+		for _, record := range user.Chat() {
+			delivered = delivered || (record.Text == text && record.Pinned)
+		}
+		if !delivered {
+			t.Errorf("expected user %d to get the pinned broadcast, got %+v", user.ID, user.Chat())
+		}
+	}
+}
+
+func TestAdminBanAndUnban(t *testing.T) {
+	h := harness.New(t, harness.Options{RootID: 1001})
+	admin := startUser(t, h, 1001)
+	user := startUser(t, h, 1002)
+
+	admin.Click(states.CallMenuAdmin)
+	admin.Click(states.CallAdminUsers)
+	replies := admin.Click(states.SelectUser + "1002")
+	expectCallback(t, replies, states.CallUserDetailBan)
+
+	admin.Click(states.CallUserDetailBan)
+	admin.Send("Spam")
+
+	if !user.Session().IsBanned {
+		t.Fatal("expected the user to be banned")
+	}
+	replies = user.Send("/menu")
+	expectText(t, replies, "bannedHeader")
+	if harness.HasCallback(replies, states.CallMenuFilms) {
+		t.Fatalf("expected a banned user to be refused the menu, got %+v", replies)
+	}
+
+	admin.Click(states.CallUserDetailUnban)
+
+	replies = user.Send("/menu")
+	expectText(t, replies, "mainMenu")
+	expectCallback(t, replies, states.CallMenuFilms)
+}
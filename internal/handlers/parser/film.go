@@ -5,6 +5,7 @@ import (
 	"github.com/k4sper1love/watchlist-bot/internal/builders/messages"
 	"github.com/k4sper1love/watchlist-bot/internal/handlers/validator"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/parsing"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
 )
 
@@ -71,6 +72,40 @@ func ParseFilmReview(app models.App, session *models.Session, retry, next func(m
 	ProcessInput(app, session, retry, next, 0, 500, utils.ParseMessageString, utils.IsValidStringLength, validator.HandleInvalidInputLength, func(s *models.Session, v string) { s.FilmDetailState.Review = v })
 }
 
+// ParseFilmSeasons processes the input for the seasons of a series, such as "10, 10, 8".
+// Validates the numbers of episodes and retries if the input is invalid.
+// Stores the description with the updated seasons in the session's FilmDetailState.
+func ParseFilmSeasons(app models.App, session *models.Session, retry, next func(models.App, *models.Session)) {
+	seasons, ok := parsing.ParseSeasonsInput(utils.ParseMessageString(app.Update))
+	if !ok {
+		validator.HandleInvalidInputSeasons(app, session)
+		retry(app, session)
+		return
+	}
+
+	description := session.FilmDetailState.Film.Description
+	series, _ := parsing.ParseSeries(description)
+	series.SetSeasons(seasons)
+	session.FilmDetailState.Description = series.SetDescription(session.Lang, description)
+	next(app, session)
+}
+
+// ParseFilmWatchedEpisode processes the input for the last watched episode of a series, such as "2 5" or "S2E5".
+// Validates that the episode is within the series and retries if the input is invalid.
+// Stores the description with the updated episode in the session's FilmDetailState.
+func ParseFilmWatchedEpisode(app models.App, session *models.Session, retry, next func(models.App, *models.Session)) {
+	description := session.FilmDetailState.Film.Description
+	series, _ := parsing.ParseSeries(description)
+	if !series.SetWatchedInput(utils.ParseMessageString(app.Update)) {
+		validator.HandleInvalidInputEpisode(app, session)
+		retry(app, session)
+		return
+	}
+
+	session.FilmDetailState.Description = series.SetDescription(session.Lang, description)
+	next(app, session)
+}
+
 // ParseFilmImageFromMessage processes the input for uploading a film's image from a message.
 // Skips the step if the user chooses to skip; otherwise, uploads the image and stores its URL.
 func ParseFilmImageFromMessage(app models.App, session *models.Session, next func(models.App, *models.Session)) {
@@ -119,6 +154,24 @@ func ParseFilmImageFromURL(app models.App, session *models.Session, imageURL str
 	next(app, session)
 }
 
+// ParseFilmImageFromPublicURL processes the image found on a page of an arbitrary site.
+// Uploads the image only if it is served from a public address and stores its URL in the session's FilmDetailState.
+// Pages without an image are left without one.
+func ParseFilmImageFromPublicURL(app models.App, session *models.Session, imageURL string, next func(models.App, *models.Session)) {
+	if imageURL == "" {
+		next(app, session)
+		return
+	}
+
+	imageURL, err := UploadImageFromPublicURL(app, imageURL)
+	if err != nil {
+		app.SendMessage(messages.ImageFailure(session), nil)
+	}
+
+	session.FilmDetailState.SetImageURL(imageURL)
+	next(app, session)
+}
+
 // ParseFilmViewed processes the input for marking a film as viewed.
 // Sets the viewed status in the session's FilmDetailState based on the user's choice.
 func ParseFilmViewed(app models.App, session *models.Session, next func(models.App, *models.Session)) {
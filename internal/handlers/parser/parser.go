@@ -48,20 +48,31 @@ func ProcessInput[T any, N int | float64](
 // Parses the image from the message and uploads it using the Watchlist service.
 // Returns the uploaded image URL or an error if parsing or uploading fails.
 func UploadImageFromMessage(app models.App) (string, error) {
-	image, err := utils.ParseImageFromMessage(app.Bot, app.Update)
+	image, err := utils.ParseImageFromMessage(app.Context(), app.Messenger, app.Update)
 	if err != nil {
 		return "", err
 	}
-	return watchlist.UploadImage(app, image)
+	return watchlist.GetClient().UploadImage(app.Context(), int(app.GetChatID()), image)
 }
 
 // UploadImageFromURL uploads an image from a URL.
 // Parses the image from the URL and uploads it using the Watchlist service.
 // Returns the uploaded image URL or an error if parsing or uploading fails.
 func UploadImageFromURL(app models.App, imageURL string) (string, error) {
-	image, err := utils.ParseImageFromURL(imageURL)
+	image, err := utils.ParseImageFromURL(app.Context(), imageURL)
 	if err != nil {
 		return "", err
 	}
-	return watchlist.UploadImage(app, image)
+	return watchlist.GetClient().UploadImage(app.Context(), int(app.GetChatID()), image)
+}
+
+// UploadImageFromPublicURL uploads an image from a URL chosen by an arbitrary page.
+// Parses the image only if it is served from a public address and uploads it using the Watchlist service.
+// Returns the uploaded image URL or an error if parsing or uploading fails.
+func UploadImageFromPublicURL(app models.App, imageURL string) (string, error) {
+	image, err := utils.ParsePublicImageFromURL(app.Context(), imageURL)
+	if err != nil {
+		return "", err
+	}
+	return watchlist.GetClient().UploadImage(app.Context(), int(app.GetChatID()), image)
 }
@@ -4,8 +4,11 @@ import (
 	"github.com/k4sper1love/watchlist-bot/internal/builders/messages"
 	"github.com/k4sper1love/watchlist-bot/internal/handlers/validator"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/client"
+	"github.com/k4sper1love/watchlist-bot/internal/services/parsing"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
 	"github.com/k4sper1love/watchlist-bot/pkg/security"
+	"net/http"
 )
 
 // ParseSettingsFilmsPageSize processes the input for the films page size setting.
@@ -30,16 +33,58 @@ func ParseSettingsObjectsPageSize(app models.App, session *models.Session, retry
 }
 
 // ParseKinopoiskToken processes the input for the Kinopoisk API token.
-// Encrypts the token using the security package and stores it in the session.
-func ParseKinopoiskToken(app models.App, session *models.Session, next func(models.App, *models.Session)) {
-	if encryptedToken, err := security.Encrypt(utils.ParseMessageString(app.Update)); err != nil {
+// Encrypts the token using the security package, deletes the user's message containing it
+// and keeps the token in the session until the user confirms it.
+// Proceeds to the confirmation step if the token is received, or to the fallback otherwise.
+func ParseKinopoiskToken(app models.App, session *models.Session, confirm, fallback func(models.App, *models.Session)) {
+	session.ClearState()
+
+	encryptedToken, err := security.Encrypt(utils.ParseMessageString(app.Update))
+	app.DeleteMessage(utils.ParseMessageID(app.Update)) // Never leave the plain token in the chat history.
+	if err != nil {
 		utils.LogEncryptError(session.TelegramID, err)
 		app.SendMessage(messages.SomeError(session), nil)
-	} else {
-		session.KinopoiskAPIToken = encryptedToken
+		fallback(app, session)
+		return
+	}
+
+	session.PendingKinopoiskToken = encryptedToken
+	confirm(app, session)
+}
+
+// TestKinopoiskToken validates the received Kinopoisk API token with a test request and saves it if it is valid.
+// Returns to the confirmation step if the token is rejected or cannot be tested.
+func TestKinopoiskToken(app models.App, session *models.Session, confirm, next func(models.App, *models.Session)) {
+	if session.PendingKinopoiskToken == "" {
+		next(app, session)
+		return
+	}
+
+	if err := parsing.CheckKinopoiskToken(app, session, session.PendingKinopoiskToken); err != nil {
+		if code := client.StatusCode(err); code == http.StatusUnauthorized || code == http.StatusForbidden || code == http.StatusTooManyRequests {
+			app.SendMessage(messages.KinopoiskFailureCode(session, code), nil)
+		} else {
+			app.SendMessage(messages.KinopoiskTokenCheckFailure(session), nil)
+		}
+		confirm(app, session)
+		return
+	}
+
+	app.SendMessage(messages.KinopoiskTokenValid(session), nil)
+	SaveKinopoiskToken(app, session, next)
+}
+
+// SaveKinopoiskToken stores the received Kinopoisk API token as the session's token.
+func SaveKinopoiskToken(app models.App, session *models.Session, next func(models.App, *models.Session)) {
+	if session.PendingKinopoiskToken != "" {
+		session.KinopoiskAPIToken, session.PendingKinopoiskToken = session.PendingKinopoiskToken, ""
 		app.SendMessage(messages.KinopoiskTokenSuccess(session), nil)
 	}
+	next(app, session)
+}
 
-	session.ClearState()
+// CancelKinopoiskToken discards the received Kinopoisk API token.
+func CancelKinopoiskToken(app models.App, session *models.Session, next func(models.App, *models.Session)) {
+	session.PendingKinopoiskToken = ""
 	next(app, session)
 }
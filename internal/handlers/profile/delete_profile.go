@@ -34,7 +34,7 @@ func handleDeleteProfileConfirm(app models.App, session *models.Session) {
 		return
 	}
 
-	if err := watchlist.DeleteUser(app, session); err != nil {
+	if err := watchlist.GetClient().DeleteUser(app.Context(), session); err != nil {
 		app.SendMessage(messages.DeleteProfileFailure(session), keyboards.Back(session, states.CallMenuProfile))
 		return
 	}
@@ -11,8 +11,11 @@ import (
 
 // HandleProfileCommand handles the command for displaying the user's profile.
 // Fetches the user's data from the Watchlist service and sends a message with their profile details.
+// While the service is unavailable, the last loaded profile is shown read-only.
 func HandleProfileCommand(app models.App, session *models.Session) {
-	if user, err := watchlist.GetUser(app, session); err != nil {
+	if user, err := watchlist.GetClient().GetUser(app.Context(), session); watchlist.IsUnavailable(err) {
+		app.SendMessage(messages.ServiceDegraded(session)+messages.Profile(session), keyboards.Back(session, ""))
+	} else if err != nil {
 		app.SendMessage(err.Error(), nil)
 	} else {
 		session.User = *user
@@ -0,0 +1,17 @@
+package profile
+
+import (
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/router"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/states"
+)
+
+// Routes returns the routes handled by the profile package.
+func Routes() []router.Route {
+	return []router.Route{
+		{Commands: []string{"profile"}, Callbacks: []string{states.CallMenuProfile}, Handler: HandleProfileCommand},
+		{Callbacks: []string{states.Profile}, Handler: HandleProfileButtons},
+		{Callbacks: []string{states.UpdateProfile}, Writes: true, Handler: HandleUpdateProfileButtons},
+		{States: []string{states.UpdateProfileAwait}, Writes: true, Handler: HandleUpdateProfileProcess},
+		{States: []string{states.DeleteProfileAwait}, Writes: true, Handler: HandleDeleteProfileProcess},
+	}
+}
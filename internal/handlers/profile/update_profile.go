@@ -76,7 +76,7 @@ func finishUpdateProfile(app models.App, session *models.Session) {
 
 // updateProfile updates the user's profile using the Watchlist service.
 func updateProfile(app models.App, session *models.Session) error {
-	user, err := watchlist.UpdateUser(app, session)
+	user, err := watchlist.GetClient().UpdateUser(app.Context(), session)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,8 @@
+// Package router provides a declarative registry of update routes for the Watchlist bot.
+//
+// Handler packages describe the commands, callback prefixes and session state prefixes they handle,
+// together with the minimum role required to use them and whether they change data in the Watchlist API.
+// Registration fails on duplicate commands and on overlapping or ambiguous prefixes,
+// so routing never depends on declaration order.
+// The registry also produces a listing of all routes for auditing access requirements.
+package router
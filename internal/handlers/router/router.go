@@ -0,0 +1,187 @@
+package router
+
+import (
+	"fmt"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/pkg/roles"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Route kinds used in the route listing.
+const (
+	KindCommand  = "command"  // Bot command, e.g. "/films".
+	KindCallback = "callback" // Callback data prefix of an inline button.
+	KindState    = "state"    // Session state prefix awaiting user input.
+)
+
+// HandlerFunc handles an update for the given session.
+type HandlerFunc = func(app models.App, session *models.Session)
+
+// GuardFunc runs the next handler only if the session has the required role.
+type GuardFunc = func(app models.App, session *models.Session, next HandlerFunc, role roles.Role)
+
+// Route describes the updates handled by a single handler.
+type Route struct {
+	Name      string      // Name shown in the route listing; derived from the handler if empty.
+	Commands  []string    // Bot commands handled by the route, without the leading slash.
+	Callbacks []string    // Callback data prefixes handled by the route.
+	States    []string    // Session state prefixes handled by the route.
+	Role      roles.Role  // Minimum role required to use the route.
+	Writes    bool        // Whether the route changes data in the Watchlist API.
+	Handler   HandlerFunc // Function handling the matched update.
+}
+
+// Router matches updates to registered routes.
+type Router struct {
+	guard     GuardFunc         // Function enforcing the required role of a route.
+	commands  map[string]*Route // Routes keyed by command.
+	callbacks map[string]*Route // Routes keyed by callback data prefix.
+	states    map[string]*Route // Routes keyed by session state prefix.
+}
+
+// New creates an empty router that uses the guard to enforce route roles.
+func New(guard GuardFunc) *Router {
+	return &Router{
+		guard:     guard,
+		commands:  make(map[string]*Route),
+		callbacks: make(map[string]*Route),
+		states:    make(map[string]*Route),
+	}
+}
+
+// Register adds the routes to the router.
+// It returns an error if a route has no handler or patterns, a command is registered twice,
+// or a callback or state prefix overlaps with an already registered one.
+func (r *Router) Register(routes ...Route) error {
+	for i := range routes {
+		route := routes[i]
+		if route.Handler == nil {
+			return fmt.Errorf("route %q has no handler", route.Name)
+		}
+		if len(route.Commands)+len(route.Callbacks)+len(route.States) == 0 {
+			return fmt.Errorf("route %q has no commands, callbacks or states", route.name())
+		}
+
+		for _, command := range route.Commands {
+			if existing, ok := r.commands[command]; ok {
+				return fmt.Errorf("command %q of %s is already registered by %s", command, route.name(), existing.name())
+			}
+			r.commands[command] = &route
+		}
+
+		if err := addPrefixes(r.callbacks, KindCallback, route.Callbacks, &route); err != nil {
+			return err
+		}
+
+		if err := addPrefixes(r.states, KindState, route.States, &route); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MatchCommand returns the route handling the command.
+func (r *Router) MatchCommand(command string) (*Route, bool) {
+	route, ok := r.commands[command]
+	return route, ok
+}
+
+// MatchCallback returns the route whose prefix matches the callback data.
+func (r *Router) MatchCallback(data string) (*Route, bool) {
+	return matchPrefix(r.callbacks, data)
+}
+
+// MatchState returns the route whose prefix matches the session state.
+func (r *Router) MatchState(state string) (*Route, bool) {
+	return matchPrefix(r.states, state)
+}
+
+// Run executes the route's handler, enforcing its required role.
+func (r *Router) Run(route *Route, app models.App, session *models.Session) {
+	if route.Role > roles.User && r.guard != nil {
+		r.guard(app, session, route.Handler, route.Role)
+		return
+	}
+	route.Handler(app, session)
+}
+
+// Listing returns a table of all registered routes with their required roles, sorted by kind and pattern.
+func (r *Router) Listing() string {
+	type entry struct {
+		kind, pattern string
+		route         *Route
+	}
+
+	var entries []entry
+	for command, route := range r.commands {
+		entries = append(entries, entry{KindCommand, "/" + command, route})
+	}
+	for prefix, route := range r.callbacks {
+		entries = append(entries, entry{KindCallback, prefix + "*", route})
+	}
+	for prefix, route := range r.states {
+		entries = append(entries, entry{KindState, prefix + "*", route})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].kind != entries[j].kind {
+			return entries[i].kind < entries[j].kind
+		}
+		return entries[i].pattern < entries[j].pattern
+	})
+
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "KIND\tPATTERN\tROLE\tHANDLER")
+	for _, e := range entries {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", e.kind, e.pattern, e.route.Role, e.route.name())
+	}
+	_ = w.Flush()
+
+	return sb.String()
+}
+
+// name returns the route name, falling back to the name of its handler function.
+func (route *Route) name() string {
+	if route.Name != "" {
+		return route.Name
+	}
+	if route.Handler == nil {
+		return "unknown"
+	}
+
+	name := runtime.FuncForPC(reflect.ValueOf(route.Handler).Pointer()).Name()
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// addPrefixes registers the prefixes of a route, rejecting any prefix that overlaps with a registered one.
+func addPrefixes(registered map[string]*Route, kind string, prefixes []string, route *Route) error {
+	for _, prefix := range prefixes {
+		if prefix == "" {
+			return fmt.Errorf("%s of %s has an empty prefix", kind, route.name())
+		}
+
+		for existing, owner := range registered {
+			if strings.HasPrefix(prefix, existing) || strings.HasPrefix(existing, prefix) {
+				return fmt.Errorf("%s prefix %q of %s overlaps with %q of %s", kind, prefix, route.name(), existing, owner.name())
+			}
+		}
+		registered[prefix] = route
+	}
+	return nil
+}
+
+// matchPrefix returns the route whose prefix matches the value.
+// Registered prefixes never overlap, so at most one route can match.
+func matchPrefix(registered map[string]*Route, value string) (*Route, bool) {
+	for prefix, route := range registered {
+		if strings.HasPrefix(value, prefix) {
+			return route, true
+		}
+	}
+	return nil, false
+}
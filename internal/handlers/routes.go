@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/admin"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/collectionFilms"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/collections"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/films"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/general"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/profile"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/router"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/states"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"log/slog"
+)
+
+var routes *router.Router // Registry of all update routes, initialized by InitRoutes.
+
+// InitRoutes registers the routes of all handler packages.
+// It returns an error if any routes overlap, so the bot fails at startup instead of misrouting updates.
+func InitRoutes() error {
+	r := router.New(general.RequireRole)
+
+	for _, group := range [][]router.Route{
+		general.Routes(),
+		admin.Routes(),
+		profile.Routes(),
+		films.Routes(),
+		collections.Routes(),
+		collectionFilms.Routes(),
+		{{Callbacks: []string{states.Films, states.SelectFilm}, Handler: handleFilmsButtons}},
+	} {
+		if err := r.Register(group...); err != nil {
+			slog.Error("failed to register routes", slog.Any("error", err))
+			return err
+		}
+	}
+
+	routes = r
+	return nil
+}
+
+// RouteListing returns a table of all registered routes and the roles they require.
+func RouteListing() string {
+	return routes.Listing()
+}
+
+// handleFilmsButtons handles the films list buttons, returning to the menu or collections depending on the context.
+func handleFilmsButtons(app models.App, session *models.Session) {
+	switch session.Context {
+	case states.CtxFilm:
+		films.HandleFilmsButtons(app, session, general.HandleMenuCommand)
+	case states.CtxCollection:
+		films.HandleFilmsButtons(app, session, collections.HandleCollectionsCommand)
+	}
+}
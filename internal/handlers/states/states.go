@@ -56,6 +56,9 @@ const (
 	CallSettingsLanguage             = Settings + "language"                   // Action to change the language.
 	CallSettingsKinopoiskToken       = Settings + "kinopoisk_token"            // Action to update the Kinopoisk API token.
 	AwaitSettingsKinopoiskToken      = SettingsAwait + "kinopoisk_token"       // State for awaiting Kinopoisk token input.
+	CallSettingsTestKinopoiskToken   = Settings + "test_kinopoisk_token"       // Action to test the received Kinopoisk API token.
+	CallSettingsSaveKinopoiskToken   = Settings + "save_kinopoisk_token"       // Action to save the received Kinopoisk API token.
+	CallSettingsCancelKinopoiskToken = Settings + "cancel_kinopoisk_token"     // Action to discard the received Kinopoisk API token.
 	CallSettingsCollectionsPageSize  = Settings + "collections_page_size"      // Action to change collections page size.
 	AwaitSettingsCollectionsPageSize = SettingsAwait + "collections_page_size" // State for awaiting collections page size input.
 	CallSettingsFilmsPageSize        = Settings + "films_page_size"            // Action to change films page size.
@@ -191,6 +194,16 @@ const (
 	CallFindNewFilmPageLast  = FindNewFilmPage + "last"  // Action to navigate to the last found new films page.
 	CallFindNewFilmPageFirst = FindNewFilmPage + "first" // Action to navigate to the first found new films page.
 
+	// Search Filters
+	SearchFilters             = FindNewFilm + "filters_"    // Prefix for search filters of new films-related states.
+	SearchFiltersSelect       = SearchFilters + "select_"   // Prefix for selecting a search filter.
+	SearchFiltersRemove       = SearchFilters + "remove_"   // Prefix for removing a search filter from the search results.
+	SearchFiltersType         = SearchFilters + "type_"     // Prefix for choosing the type of searched films.
+	SearchFiltersAwait        = SearchFilters + "await_"    // Prefix for awaiting search filter input.
+	CallSearchFilters         = SearchFilters + "menu"      // Action to open the search filters.
+	CallSearchFiltersSearch   = SearchFilters + "search"    // Action to search with the search filters.
+	CallSearchFiltersAllReset = SearchFilters + "all_reset" // Action to reset all search filters.
+
 	// Film Filters
 	FilmFilters                           = "film_filters_"                         // Prefix for film filters-related states.
 	FilmFiltersSelect                     = FilmFilters + "select_"                 // Prefix for selecting film filters.
@@ -237,7 +250,11 @@ const (
 	CallNewFilmManually             = NewFilm + "manually"               // Action to add a film manually.
 	CallNewFilmFromURL              = NewFilm + "from_url"               // Action to add a film from a URL.
 	CallNewFilmFind                 = NewFilm + "find"                   // Action to search for a film.
+	CallNewFilmFindAnime            = NewFilm + "find_anime"             // Action to search for an anime.
 	CallNewFilmChangeKinopoiskToken = NewFilm + "change_kinopoisk_token" // Action to change the Kinopoisk API token.
+	CallNewFilmTestKinopoiskToken   = NewFilm + "test_kinopoisk_token"   // Action to test the received Kinopoisk API token.
+	CallNewFilmSaveKinopoiskToken   = NewFilm + "save_kinopoisk_token"   // Action to save the received Kinopoisk API token.
+	CallNewFilmCancelKinopoiskToken = NewFilm + "cancel_kinopoisk_token" // Action to discard the received Kinopoisk API token.
 	AwaitNewFilmFind                = NewFilmAwait + "find"              // State for awaiting film search input.
 	AwaitNewFilmFromURL             = NewFilmAwait + "from_url"          // State for awaiting film URL input.
 	AwaitNewFilmTitle               = NewFilmAwait + "title"             // State for awaiting film title input.
@@ -284,6 +301,10 @@ const (
 	AwaitUpdateFilmUserRating  = UpdateFilmAwait + "user_rating" // State for awaiting user rating input.
 	CallUpdateFilmReview       = UpdateFilm + "review"           // Action to update the film review.
 	AwaitUpdateFilmReview      = UpdateFilmAwait + "review"      // State for awaiting film review input.
+	CallUpdateFilmSeasons      = UpdateFilm + "seasons"          // Action to update the seasons of a series.
+	AwaitUpdateFilmSeasons     = UpdateFilmAwait + "seasons"     // State for awaiting the seasons input.
+	CallUpdateFilmWatched      = UpdateFilm + "watched"          // Action to update the last watched episode of a series.
+	AwaitUpdateFilmWatched     = UpdateFilmAwait + "watched"     // State for awaiting the last watched episode input.
 
 	// Film Detail
 	FilmDetail             = "film_detail_"          // Prefix for viewing film details.
@@ -293,6 +314,7 @@ const (
 	CallFilmDetailBack     = FilmDetail + "back"     // Action to go back from film details.
 	CallFilmDetailViewed   = FilmDetail + "viewed"   // Action to mark a film as viewed.
 	CallFilmDetailFavorite = FilmDetail + "favorite" // Action to mark a film as favorite.
+	CallFilmDetailEpisode  = FilmDetail + "episode"  // Action to mark the next episode of a series as watched.
 
 	// Viewed Film
 	ViewedFilm                = "viewed_film_"                  // Prefix for viewed film-related states.
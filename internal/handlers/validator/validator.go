@@ -41,3 +41,13 @@ func HandleInvalidInputEmail(app models.App, session *models.Session, minLength,
 		"Max": maxLength,
 	}), nil)
 }
+
+// HandleInvalidInputSeasons sends a validation warning to the user when the input of the seasons of a series is invalid.
+func HandleInvalidInputSeasons(app models.App, session *models.Session) {
+	app.SendMessage(messages.ValidationWarning(session, "invalidInputSeasons", nil), nil)
+}
+
+// HandleInvalidInputEpisode sends a validation warning to the user when the input episode is not within the series.
+func HandleInvalidInputEpisode(app models.App, session *models.Session) {
+	app.SendMessage(messages.ValidationWarning(session, "invalidInputEpisode", nil), nil)
+}
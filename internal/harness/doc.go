@@ -0,0 +1,26 @@
+// Package harness runs scripted conversations with the bot for end-to-end tests of handler flows.
+//
+// A Harness wires the real update handlers to test doubles:
+//   - a messenger.Recorder instead of the Telegram transport, capturing every text, photo, edit and keyboard;
+//   - a fakeapi.Server instead of the Watchlist API;
+//   - an in-memory SQLite database instead of PostgreSQL.
+//
+// Updates are handled synchronously, one at a time, exactly as HandleUpdates processes them in production,
+// so a test can send a message or press a button and immediately assert on the replies and the saved session.
+//
+// The database, API client, translator and routes are package-level globals of the bot, so a Harness
+// must not be used from parallel tests.
+//
+// Usage:
+//
+//	h := harness.New(t, harness.Options{})
+//	user := h.User(1001)
+//
+//	replies := user.Send("/start")
+//	user.Click(states.SelectStartLang + "en")
+//
+//	session := user.Session()
+//	if session.Lang != "en" {
+//		t.Fatalf("expected English, got %q", session.Lang)
+//	}
+package harness
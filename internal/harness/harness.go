@@ -0,0 +1,158 @@
+package harness
+
+import (
+	"context"
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/k4sper1love/watchlist-bot/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-bot/internal/fakeapi"
+	"github.com/k4sper1love/watchlist-bot/internal/handlers"
+	"github.com/k4sper1love/watchlist-bot/internal/messenger"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/watchlist"
+	"github.com/k4sper1love/watchlist-bot/pkg/logger"
+	"github.com/k4sper1love/watchlist-bot/pkg/translator"
+	"gorm.io/driver/sqlite"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const (
+	apiSecret = "harness-api-secret"               // Secret shared by the bot and the fake API.
+	masterKey = "harness-master-key-0123456789abc" // 32-byte key used to encrypt the stored tokens.
+	botID     = 1                                  // Telegram ID of the bot user.
+)
+
+// Options configures a Harness.
+type Options struct {
+	RootID int                  // Telegram ID of the root user; zero means no root user.
+	Config func(*models.Config) // Optional function adjusting the bot configuration.
+}
+
+// Harness runs the bot's update handlers against a recorder, a fake API and an in-memory database.
+type Harness struct {
+	t        testing.TB          // Test owning the harness.
+	Config   *models.Config      // Configuration passed to the handlers.
+	Recorder *messenger.Recorder // Recorder capturing everything the bot sends.
+	API      *fakeapi.Server     // Fake Watchlist API used by the bot.
+	ctx      context.Context     // Context of the handled updates.
+	nextMsg  int                 // ID assigned to the next user message.
+}
+
+// New creates a harness for the test. All resources are released when the test finishes.
+func New(t testing.TB, options Options) *Harness {
+	t.Helper()
+
+	t.Setenv("MASTER_KEY", masterKey)
+	t.Setenv("LOGS_DIR", t.TempDir())
+	t.Cleanup(func() { _ = logger.Close() })
+
+	root := moduleRoot(t)
+	config := &models.Config{
+		Version:    "test",
+		LocalesDir: filepath.Join(root, "locales"),
+		APISecret:  apiSecret,
+		RootID:     options.RootID,
+	}
+	if options.Config != nil {
+		options.Config(config)
+	}
+
+	if err := translator.Init(config.LocalesDir); err != nil {
+		t.Fatalf("failed to initialize translator: %v", err)
+	}
+	if err := handlers.InitRoutes(); err != nil {
+		t.Fatalf("failed to register routes: %v", err)
+	}
+
+	if err := postgres.OpenDatabase(sqlite.Open(databaseDSN(t))); err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { _ = postgres.CloseDatabase() })
+
+	api := fakeapi.NewServer(fakeapi.Options{Secret: apiSecret})
+	t.Cleanup(api.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	watchlist.Init(watchlist.NewWatchlistClient(watchlist.Options{
+		Context:          ctx,
+		BaseURL:          api.URL,
+		APISecret:        apiSecret,
+		FailureThreshold: config.WatchlistBreakerThreshold,
+		OpenTimeout:      config.WatchlistBreakerTimeout,
+		SaveTokens:       postgres.SaveSessionTokens,
+	}))
+
+	return &Harness{
+		t:        t,
+		Config:   config,
+		Recorder: messenger.NewRecorder(tgbotapi.User{ID: botID, UserName: "watchlist_test_bot", IsBot: true}),
+		API:      api,
+		ctx:      ctx,
+		nextMsg:  1,
+	}
+}
+
+// User returns a user talking to the bot with the given Telegram ID and English as the client language.
+func (h *Harness) User(telegramID int) *User {
+	return &User{
+		h:        h,
+		ID:       telegramID,
+		Username: fmt.Sprintf("user%d", telegramID),
+		Lang:     "en",
+	}
+}
+
+// handle handles the update synchronously and returns the messages the bot produced for it.
+func (h *Harness) handle(update *tgbotapi.Update) []messenger.Record {
+	h.t.Helper()
+
+	before := len(h.Recorder.Records())
+	handlers.HandleUpdates(h.app(update))
+	return h.Recorder.Records()[before:]
+}
+
+// app builds the application context for the update.
+func (h *Harness) app(update *tgbotapi.Update) models.App {
+	return models.App{
+		Ctx:       h.ctx,
+		Config:    h.Config,
+		Messenger: h.Recorder,
+		Update:    update,
+		Logger:    logger.Get(telegramIDOf(update)),
+	}
+}
+
+// messageID returns a new ID for a message sent by a user.
+func (h *Harness) messageID() int {
+	id := h.nextMsg
+	h.nextMsg++
+	return -id // User messages get negative IDs so they never collide with the recorder's IDs.
+}
+
+// databaseDSN returns the DSN of an in-memory SQLite database private to the test.
+func databaseDSN(t testing.TB) string {
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	return fmt.Sprintf("file:%s?mode=memory&cache=shared", name)
+}
+
+// moduleRoot returns the root directory of the module, where the locales are stored.
+func moduleRoot(t testing.TB) string {
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("failed to locate the harness source file")
+	}
+	return filepath.Join(filepath.Dir(file), "..", "..")
+}
+
+// telegramIDOf returns the ID of the user who sent the update.
+func telegramIDOf(update *tgbotapi.Update) int {
+	if update.CallbackQuery != nil {
+		return update.CallbackQuery.From.ID
+	}
+	return update.Message.From.ID
+}
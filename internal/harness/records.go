@@ -0,0 +1,53 @@
+package harness
+
+import (
+	"github.com/k4sper1love/watchlist-bot/internal/messenger"
+	"strings"
+)
+
+// Callbacks returns the callback data of every inline button attached to the record, row by row.
+func Callbacks(record messenger.Record) []string {
+	if record.Keyboard == nil {
+		return nil
+	}
+
+	var data []string
+	for _, row := range record.Keyboard.InlineKeyboard {
+		for _, button := range row {
+			if button.CallbackData != nil {
+				data = append(data, *button.CallbackData)
+			}
+		}
+	}
+	return data
+}
+
+// HasCallback reports whether any of the records has a button with the given callback data.
+func HasCallback(records []messenger.Record, data string) bool {
+	for _, record := range records {
+		for _, callback := range Callbacks(record) {
+			if callback == data {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasText reports whether the text of any of the records contains the substring.
+func HasText(records []messenger.Record, substr string) bool {
+	for _, record := range records {
+		if strings.Contains(record.Text, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Last returns the last of the records, or an empty record if there are none.
+func Last(records []messenger.Record) messenger.Record {
+	if len(records) == 0 {
+		return messenger.Record{}
+	}
+	return records[len(records)-1]
+}
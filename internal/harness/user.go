@@ -0,0 +1,100 @@
+package harness
+
+import (
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/k4sper1love/watchlist-bot/internal/database/postgres"
+	"github.com/k4sper1love/watchlist-bot/internal/messenger"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"strings"
+	"time"
+)
+
+// User is a Telegram user talking to the bot in a harness.
+type User struct {
+	h         *Harness // Harness handling the user's updates.
+	ID        int      // Telegram ID of the user.
+	Username  string   // Telegram username of the user.
+	Lang      string   // Language code of the user's Telegram client.
+	callbacks int      // Number of callback queries sent by the user.
+}
+
+// Send sends a text message as the user and returns the messages the bot produced in reply.
+// Text starting with "/" is sent as a command.
+func (u *User) Send(text string) []messenger.Record {
+	u.h.t.Helper()
+
+	message := u.message(text)
+	if strings.HasPrefix(text, "/") {
+		length := len(strings.Fields(text)[0])
+		message.Entities = &[]tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: length}}
+	}
+
+	return u.h.handle(&tgbotapi.Update{Message: message})
+}
+
+// Click presses the button with the given callback data on the bot's last message to the user
+// and returns the messages the bot produced in reply.
+func (u *User) Click(data string) []messenger.Record {
+	u.h.t.Helper()
+
+	u.callbacks++
+	query := &tgbotapi.CallbackQuery{
+		ID:      fmt.Sprintf("%d-%d", u.ID, u.callbacks),
+		From:    u.telegramUser(),
+		Message: u.lastBotMessage(),
+		Data:    data,
+	}
+
+	return u.h.handle(&tgbotapi.Update{CallbackQuery: query})
+}
+
+// Session returns the user's session as saved in the database.
+func (u *User) Session() *models.Session {
+	u.h.t.Helper()
+
+	session, err := postgres.GetSessionByTelegramID(u.h.app(&tgbotapi.Update{Message: u.message("")}))
+	if err != nil {
+		u.h.t.Fatalf("failed to load session of user %d: %v", u.ID, err)
+	}
+	return session
+}
+
+// Chat returns every message the bot has sent to the user so far.
+func (u *User) Chat() []messenger.Record {
+	var records []messenger.Record
+	for _, record := range u.h.Recorder.Records() {
+		if record.ChatID == int64(u.ID) {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// message builds a message with the text sent by the user in their private chat with the bot.
+func (u *User) message(text string) *tgbotapi.Message {
+	return &tgbotapi.Message{
+		MessageID: u.h.messageID(),
+		From:      u.telegramUser(),
+		Date:      int(time.Now().Unix()),
+		Chat:      &tgbotapi.Chat{ID: int64(u.ID), Type: "private", UserName: u.Username},
+		Text:      text,
+	}
+}
+
+// lastBotMessage returns the bot's last message to the user, or an empty message if there is none.
+func (u *User) lastBotMessage() *tgbotapi.Message {
+	message := &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: int64(u.ID), Type: "private", UserName: u.Username}}
+
+	if chat := u.Chat(); len(chat) > 0 {
+		last := chat[len(chat)-1]
+		message.MessageID = last.MessageID
+		message.Text = last.Text
+	}
+	return message
+}
+
+// telegramUser returns the Telegram profile of the user.
+func (u *User) telegramUser() *tgbotapi.User {
+	return &tgbotapi.User{ID: u.ID, UserName: u.Username, LanguageCode: u.Lang}
+}
@@ -0,0 +1,7 @@
+// Package messenger abstracts the Telegram transport used by the bot.
+//
+// It defines the Messenger interface covering sending, editing and pinning messages,
+// answering callback queries and resolving files. The Telegram implementation wraps
+// the tgbotapi client and is used by default, while Recorder keeps everything in memory
+// so that tests can check which messages and keyboards a flow produced.
+package messenger
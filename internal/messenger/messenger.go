@@ -0,0 +1,29 @@
+package messenger
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// Messenger is the transport used by the bot to communicate with Telegram users.
+type Messenger interface {
+	// Self returns the bot user.
+	Self() tgbotapi.User
+
+	// Send sends a message, photo or document and returns the sent message.
+	Send(msg tgbotapi.Chattable) (tgbotapi.Message, error)
+
+	// Edit replaces the text and keyboard of a previously sent message.
+	Edit(config tgbotapi.EditMessageTextConfig) error
+
+	// Delete deletes a message from the chat.
+	Delete(chatID int64, messageID int) error
+
+	// Pin pins a message in the chat.
+	Pin(chatID int64, messageID int) error
+
+	// AnswerCallback acknowledges a callback query.
+	AnswerCallback(callbackID string) error
+
+	// GetFileURL returns a download URL for the file with the given ID.
+	GetFileURL(fileID string) (string, error)
+}
@@ -0,0 +1,230 @@
+package messenger
+
+import (
+	"fmt"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"sync"
+)
+
+// Message kinds recorded by Recorder.
+const (
+	KindText     = "text"     // Text message.
+	KindPhoto    = "photo"    // Photo with an optional caption.
+	KindDocument = "document" // Document with an optional caption.
+	KindEdit     = "edit"     // Edit of a previously sent message.
+)
+
+// MessageRef identifies a message in a chat.
+type MessageRef struct {
+	ChatID    int64 // ID of the chat containing the message.
+	MessageID int   // ID of the message.
+}
+
+// Record describes a single message produced by the bot.
+type Record struct {
+	Kind      string                         // Kind of the message (text, photo, document or edit).
+	ChatID    int64                          // ID of the chat the message was sent to.
+	MessageID int                            // ID assigned to the message by the recorder.
+	Text      string                         // Text or caption of the message.
+	ParseMode string                         // Parse mode used for the text.
+	File      string                         // Path of the uploaded photo or document.
+	Keyboard  *tgbotapi.InlineKeyboardMarkup // Inline keyboard attached to the message.
+	Pinned    bool                           // Whether the message was pinned.
+}
+
+// Recorder is an in-memory Messenger implementation that records every message instead of sending it.
+// It is safe for concurrent use.
+type Recorder struct {
+	self            tgbotapi.User     // Bot user returned by Self.
+	records         []Record          // Messages produced so far.
+	callbackAnswers []string          // IDs of answered callback queries.
+	deleted         []MessageRef      // Messages deleted by the bot.
+	files           map[string]string // Download URLs keyed by file ID.
+	nextID          int               // ID assigned to the next message.
+	mu              sync.Mutex        // Mutex guarding the recorded data.
+}
+
+// NewRecorder creates an empty Recorder acting as the given bot user.
+func NewRecorder(self tgbotapi.User) *Recorder {
+	return &Recorder{
+		self:   self,
+		files:  make(map[string]string),
+		nextID: 1,
+	}
+}
+
+// Self returns the bot user.
+func (r *Recorder) Self() tgbotapi.User {
+	return r.self
+}
+
+// Send records a text message, photo or document and returns it as if it was sent.
+func (r *Recorder) Send(msg tgbotapi.Chattable) (tgbotapi.Message, error) {
+	record, err := toRecord(msg)
+	if err != nil {
+		return tgbotapi.Message{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record.MessageID = r.nextID
+	r.nextID++
+	r.records = append(r.records, record)
+
+	return tgbotapi.Message{
+		MessageID: record.MessageID,
+		Chat:      &tgbotapi.Chat{ID: record.ChatID},
+		Text:      record.Text,
+	}, nil
+}
+
+// Edit records an edit of a previously sent message.
+func (r *Recorder) Edit(config tgbotapi.EditMessageTextConfig) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records = append(r.records, Record{
+		Kind:      KindEdit,
+		ChatID:    config.ChatID,
+		MessageID: config.MessageID,
+		Text:      config.Text,
+		ParseMode: config.ParseMode,
+		Keyboard:  config.ReplyMarkup,
+	})
+	return nil
+}
+
+// Delete records the deletion of a message.
+// User messages are not recorded, so any message ID is accepted.
+func (r *Recorder) Delete(chatID int64, messageID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleted = append(r.deleted, MessageRef{ChatID: chatID, MessageID: messageID})
+	return nil
+}
+
+// Pin marks a recorded message as pinned.
+func (r *Recorder) Pin(chatID int64, messageID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.records {
+		if r.records[i].ChatID == chatID && r.records[i].MessageID == messageID {
+			r.records[i].Pinned = true
+			return nil
+		}
+	}
+	return fmt.Errorf("message %d not found in chat %d", messageID, chatID)
+}
+
+// AnswerCallback records the answered callback query.
+func (r *Recorder) AnswerCallback(callbackID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.callbackAnswers = append(r.callbackAnswers, callbackID)
+	return nil
+}
+
+// GetFileURL returns the download URL registered for the file with SetFileURL.
+func (r *Recorder) GetFileURL(fileID string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if fileURL, ok := r.files[fileID]; ok {
+		return fileURL, nil
+	}
+	return "", fmt.Errorf("file %s not found", fileID)
+}
+
+// SetFileURL registers a download URL for the file with the given ID.
+func (r *Recorder) SetFileURL(fileID, fileURL string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.files[fileID] = fileURL
+}
+
+// Records returns a copy of all recorded messages in the order they were produced.
+func (r *Recorder) Records() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Record(nil), r.records...)
+}
+
+// Last returns the most recently recorded message, if any.
+func (r *Recorder) Last() (Record, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.records) == 0 {
+		return Record{}, false
+	}
+	return r.records[len(r.records)-1], true
+}
+
+// CallbackAnswers returns the IDs of all answered callback queries.
+func (r *Recorder) CallbackAnswers() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.callbackAnswers...)
+}
+
+// Deleted returns all messages deleted by the bot.
+func (r *Recorder) Deleted() []MessageRef {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]MessageRef(nil), r.deleted...)
+}
+
+// Reset clears all recorded messages, callback answers and deletions.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records = nil
+	r.callbackAnswers = nil
+	r.deleted = nil
+}
+
+// toRecord converts a supported message config into a record.
+func toRecord(msg tgbotapi.Chattable) (Record, error) {
+	switch m := msg.(type) {
+	case tgbotapi.MessageConfig:
+		return Record{Kind: KindText, ChatID: m.ChatID, Text: m.Text, ParseMode: m.ParseMode, Keyboard: inlineKeyboard(m.ReplyMarkup)}, nil
+
+	case tgbotapi.PhotoConfig:
+		return Record{Kind: KindPhoto, ChatID: m.ChatID, Text: m.Caption, ParseMode: m.ParseMode, File: fileName(m.File), Keyboard: inlineKeyboard(m.ReplyMarkup)}, nil
+
+	case tgbotapi.DocumentConfig:
+		return Record{Kind: KindDocument, ChatID: m.ChatID, Text: m.Caption, ParseMode: m.ParseMode, File: fileName(m.File), Keyboard: inlineKeyboard(m.ReplyMarkup)}, nil
+
+	default:
+		return Record{}, fmt.Errorf("unsupported message type %T", msg)
+	}
+}
+
+// inlineKeyboard extracts an inline keyboard from reply markup.
+func inlineKeyboard(markup interface{}) *tgbotapi.InlineKeyboardMarkup {
+	switch keyboard := markup.(type) {
+	case *tgbotapi.InlineKeyboardMarkup:
+		return keyboard
+	case tgbotapi.InlineKeyboardMarkup:
+		return &keyboard
+	default:
+		return nil
+	}
+}
+
+// fileName returns the path of an uploaded file if it is given as a string.
+func fileName(file interface{}) string {
+	if name, ok := file.(string); ok {
+		return name
+	}
+	return ""
+}
@@ -0,0 +1,60 @@
+package messenger
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// Telegram is the Messenger implementation backed by the Telegram Bot API.
+type Telegram struct {
+	bot *tgbotapi.BotAPI // Telegram bot API client.
+}
+
+// NewTelegram creates a Messenger that uses the given Telegram bot API client.
+func NewTelegram(bot *tgbotapi.BotAPI) *Telegram {
+	return &Telegram{bot: bot}
+}
+
+// Self returns the bot user.
+func (t *Telegram) Self() tgbotapi.User {
+	return t.bot.Self
+}
+
+// Send sends a message, photo or document and returns the sent message.
+func (t *Telegram) Send(msg tgbotapi.Chattable) (tgbotapi.Message, error) {
+	return t.bot.Send(msg)
+}
+
+// Edit replaces the text and keyboard of a previously sent message.
+func (t *Telegram) Edit(config tgbotapi.EditMessageTextConfig) error {
+	_, err := t.bot.Send(config)
+	return err
+}
+
+// Delete deletes a message from the chat.
+func (t *Telegram) Delete(chatID int64, messageID int) error {
+	_, err := t.bot.DeleteMessage(tgbotapi.DeleteMessageConfig{
+		ChatID:    chatID,
+		MessageID: messageID,
+	})
+	return err
+}
+
+// Pin pins a message in the chat.
+func (t *Telegram) Pin(chatID int64, messageID int) error {
+	_, err := t.bot.PinChatMessage(tgbotapi.PinChatMessageConfig{
+		ChatID:    chatID,
+		MessageID: messageID,
+	})
+	return err
+}
+
+// AnswerCallback acknowledges a callback query.
+func (t *Telegram) AnswerCallback(callbackID string) error {
+	_, err := t.bot.AnswerCallbackQuery(tgbotapi.CallbackConfig{CallbackQueryID: callbackID})
+	return err
+}
+
+// GetFileURL returns a download URL for the file with the given ID.
+func (t *Telegram) GetFileURL(fileID string) (string, error) {
+	return t.bot.GetFileDirectURL(fileID)
+}
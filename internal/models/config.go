@@ -1,11 +1,15 @@
 package models
 
 import (
+	"context"
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/k4sper1love/watchlist-bot/internal/messenger"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
 	"github.com/k4sper1love/watchlist-bot/pkg/logger"
 	"github.com/k4sper1love/watchlist-bot/pkg/translator"
+	"strings"
+	"time"
 	"unicode/utf8"
 )
 
@@ -16,10 +20,11 @@ const (
 
 // App represents the main application structure, encapsulating configuration, bot API, and logging.
 type App struct {
-	Config *Config          // Application configuration.
-	Bot    *tgbotapi.BotAPI // Telegram bot API instance.
-	Update *tgbotapi.Update // Incoming update from Telegram.
-	Logger *logger.Wrapper  // Logger for recording application events.
+	Ctx       context.Context     // Context of the update, canceled if its handler must be aborted.
+	Config    *Config             // Application configuration.
+	Messenger messenger.Messenger // Transport used to communicate with Telegram.
+	Update    *tgbotapi.Update    // Incoming update from Telegram.
+	Logger    *logger.Wrapper     // Logger for recording application events.
 }
 
 // Config contains the application's configuration settings.
@@ -33,7 +38,31 @@ type Config struct {
 	APISecret       string // Secret key for API verification.
 	RootID          int    // Root user ID for admin purposes.
 	YoutubeAPIToken string // YouTube API token.
-	IMDBAPIToken    string // IMDB API token.
+	IMDBAPIToken    string // OMDb API key for IMDb films (optional).
+	TMDBAPIToken    string // TMDB API read access token used for search and URL import.
+
+	UpdatesMode       string // Update delivery mode ("polling" or "webhook").
+	WebhookURL        string // Public URL registered with Telegram in webhook mode.
+	WebhookListenAddr string // Address the webhook HTTP server listens on.
+	WebhookSecret     string // Secret token expected in the webhook request header.
+	WebhookCertFile   string // Optional TLS certificate file for the webhook server.
+	WebhookKeyFile    string // Optional TLS key file for the webhook server.
+
+	MaxConcurrentUpdates int           // Maximum number of updates handled at the same time across all users.
+	UserQueueSize        int           // Maximum number of pending updates queued for a single user.
+	WorkerIdleTimeout    time.Duration // Idle period after which a per-user worker is stopped.
+	ShutdownTimeout      time.Duration // Maximum time to wait for running handlers on shutdown.
+
+	WatchlistTimeout   time.Duration // Timeout of a single request to the Watchlist API.
+	KinopoiskTimeout   time.Duration // Timeout of a single request to the Kinopoisk API.
+	ExternalTimeout    time.Duration // Timeout of a single request to other external services.
+	ImageTimeout       time.Duration // Timeout of a single image download.
+	HTTPMaxAttempts    int           // Maximum number of attempts for idempotent outbound requests.
+	HTTPRetryBaseDelay time.Duration // Delay before the first retry of an outbound request.
+
+	ListCacheTTL              time.Duration // Time for which film and collection lists of a user are cached.
+	WatchlistBreakerThreshold int           // Consecutive failures after which the Watchlist API is considered unavailable.
+	WatchlistBreakerTimeout   time.Duration // Interval between recovery probes of an unavailable Watchlist API.
 }
 
 // MessageConfig defines the configuration for sending messages, including chat ID, message ID, text, and media.
@@ -46,6 +75,14 @@ type MessageConfig struct {
 	File      string // Path to a file to send.
 }
 
+// Context returns the context of the update, or a background context if none is set.
+func (app App) Context() context.Context {
+	if app.Ctx == nil {
+		return context.Background()
+	}
+	return app.Ctx
+}
+
 // GetChatID retrieves the chat ID from the incoming update.
 func (app App) GetChatID() int64 {
 	if app.Update.Message != nil {
@@ -64,7 +101,7 @@ func (app App) logWithPrefix(prefix string) *logger.Wrapper {
 
 // LogAsBot creates a logger with a bot-specific prefix.
 func (app App) LogAsBot() *logger.Wrapper {
-	return app.logWithPrefix(fmt.Sprintf("BOT %s (%s)", app.Bot.Self.UserName, app.Config.Version))
+	return app.logWithPrefix(fmt.Sprintf("BOT %s (%s)", app.Messenger.Self().UserName, app.Config.Version))
 }
 
 // LogAsUser creates a logger with a user-specific prefix.
@@ -92,7 +129,7 @@ func (app App) logMessage(config MessageConfig) {
 		logStr += fmt.Sprintf("\n[image] %s", config.ImageURL)
 	}
 	if config.Text != "" {
-		logStr += fmt.Sprintf("\n%s", config.Text)
+		logStr += fmt.Sprintf("\n%s", utils.RedactSecrets(config.Text))
 	}
 
 	app.LogAsBot().Print(logStr)
@@ -105,7 +142,11 @@ func (app App) send(msg tgbotapi.Chattable, config MessageConfig) {
 		return
 	}
 
-	sentMsg, err := app.Bot.Send(msg)
+	sentMsg, err := app.Messenger.Send(msg)
+	if err != nil && isParseEntitiesError(err) {
+		utils.LogMessageError(fmt.Errorf("markup rejected, resending as plain text: %v", err), app.GetChatID(), -1)
+		sentMsg, err = app.Messenger.Send(toPlainText(msg))
+	}
 	if err != nil {
 		utils.LogMessageError(err, app.GetChatID(), -1)
 		return
@@ -115,11 +156,7 @@ func (app App) send(msg tgbotapi.Chattable, config MessageConfig) {
 	config.MessageID = sentMsg.MessageID
 
 	if config.NeedPin {
-		_, err = app.Bot.PinChatMessage(tgbotapi.PinChatMessageConfig{
-			ChatID:    config.ChatID,
-			MessageID: config.MessageID,
-		})
-		if err != nil {
+		if err = app.Messenger.Pin(config.ChatID, config.MessageID); err != nil {
 			utils.LogMessageError(fmt.Errorf("failed to pin message: %v", err), config.ChatID, config.MessageID)
 		}
 	}
@@ -186,7 +223,7 @@ func (app App) SendMessage(text string, keyboard *tgbotapi.InlineKeyboardMarkup)
 
 // SendImage sends an image with optional caption and keyboard markup.
 func (app App) SendImage(imageURL, text string, keyboard *tgbotapi.InlineKeyboardMarkup) {
-	imagePath, err := utils.DownloadImage(imageURL)
+	imagePath, err := utils.DownloadImage(app.Context(), imageURL)
 	if err != nil {
 		app.handleDownloadImageError()
 		return
@@ -205,7 +242,7 @@ func (app App) SendBroadcastMessage(ids []int, needPin bool, text string, keyboa
 
 // SendBroadcastImage sends a broadcast image with optional caption to multiple users.
 func (app App) SendBroadcastImage(ids []int, needPin bool, imageURL, text string, keyboard *tgbotapi.InlineKeyboardMarkup) {
-	imagePath, err := utils.DownloadImage(imageURL)
+	imagePath, err := utils.DownloadImage(app.Context(), imageURL)
 	if err != nil {
 		app.handleDownloadImageError()
 		return
@@ -224,7 +261,7 @@ func (app App) SendMessageByID(id int, text string, keyboard *tgbotapi.InlineKey
 
 // SendImageByID sends an image to a specific user by their ID.
 func (app App) SendImageByID(id int, imageURL, text string, keyboard *tgbotapi.InlineKeyboardMarkup) {
-	imagePath, err := utils.DownloadImage(imageURL)
+	imagePath, err := utils.DownloadImage(app.Context(), imageURL)
 	if err != nil {
 		app.handleDownloadImageError()
 		return
@@ -234,6 +271,33 @@ func (app App) SendImageByID(id int, imageURL, text string, keyboard *tgbotapi.I
 	app.createTemp(id).SendImage(imagePath, text, keyboard)
 }
 
+// EditMessage replaces the text and keyboard of a previously sent message in the current chat.
+func (app App) EditMessage(messageID int, text string, keyboard *tgbotapi.InlineKeyboardMarkup) {
+	msg := tgbotapi.NewEditMessageText(app.GetChatID(), messageID, text)
+	msg.ParseMode = "HTML"
+	msg.ReplyMarkup = keyboard
+
+	err := app.Messenger.Edit(msg)
+	if err != nil && isParseEntitiesError(err) {
+		msg.Text = utils.StripHTML(msg.Text)
+		msg.ParseMode = ""
+		err = app.Messenger.Edit(msg)
+	}
+	if err != nil {
+		utils.LogMessageError(err, app.GetChatID(), messageID)
+		return
+	}
+
+	app.logMessage(MessageConfig{ChatID: app.GetChatID(), MessageID: messageID, Text: text})
+}
+
+// DeleteMessage deletes a message from the current chat.
+func (app App) DeleteMessage(messageID int) {
+	if err := app.Messenger.Delete(app.GetChatID(), messageID); err != nil {
+		utils.LogDeleteMessageError(err, app.GetChatID(), messageID)
+	}
+}
+
 // SendFile sends a file with optional caption and keyboard markup.
 func (app App) SendFile(filepath string, text string, keyboard *tgbotapi.InlineKeyboardMarkup) {
 	msg := tgbotapi.NewDocumentUpload(app.GetChatID(), filepath)
@@ -246,11 +310,37 @@ func (app App) SendFile(filepath string, text string, keyboard *tgbotapi.InlineK
 	app.send(msg, MessageConfig{File: filepath, Text: text})
 }
 
+// isParseEntitiesError reports whether Telegram rejected the message because of invalid HTML markup.
+func isParseEntitiesError(err error) bool {
+	return strings.Contains(err.Error(), "can't parse entities")
+}
+
+// toPlainText returns a copy of the message with HTML markup stripped and the parse mode disabled.
+func toPlainText(msg tgbotapi.Chattable) tgbotapi.Chattable {
+	switch m := msg.(type) {
+	case tgbotapi.MessageConfig:
+		m.Text = utils.StripHTML(m.Text)
+		m.ParseMode = ""
+		return m
+	case tgbotapi.PhotoConfig:
+		m.Caption = utils.StripHTML(m.Caption)
+		m.ParseMode = ""
+		return m
+	case tgbotapi.DocumentConfig:
+		m.Caption = utils.StripHTML(m.Caption)
+		m.ParseMode = ""
+		return m
+	default:
+		return msg
+	}
+}
+
 // createTemp creates a temporary App instance for sending messages to a specific user.
 func (app App) createTemp(id int) *App {
 	return &App{
-		Bot:    app.Bot,
-		Config: app.Config,
+		Ctx:       app.Ctx,
+		Messenger: app.Messenger,
+		Config:    app.Config,
 		Update: &tgbotapi.Update{
 			Message: &tgbotapi.Message{
 				Chat: &tgbotapi.Chat{
@@ -28,6 +28,23 @@ type FilmFilters struct {
 	HasURL         *bool  `json:"-"` // Filter for whether the film has an associated URL.
 }
 
+// SearchFilters represents the refinements of a search for new films on Kinopoisk.
+// The values are kept in the form the Kinopoisk API expects them.
+type SearchFilters struct {
+	Year    string `json:"year,omitempty"`    // Release year range (e.g., "2000-2010").
+	Type    string `json:"type,omitempty"`    // Kinopoisk type of the films (e.g., "movie", "tv-series").
+	Genre   string `json:"genre,omitempty"`   // Genre as named on Kinopoisk (e.g., "драма").
+	Country string `json:"country,omitempty"` // Country as named on Kinopoisk (e.g., "США").
+	Rating  string `json:"rating,omitempty"`  // Minimum Kinopoisk rating or a rating range (e.g., "7" or "6-8").
+}
+
+// SearchFilterFields lists the fields of search filters in the order they are shown.
+// The field names are also the translation keys of their labels.
+var SearchFilterFields = []string{"year", "filmType", "genre", "country", "minRating"}
+
+// SearchFilterTypes lists the Kinopoisk types of films a search can be refined to.
+var SearchFilterTypes = []string{"movie", "tv-series", "cartoon", "anime"}
+
 // Sorting represents sorting options applied to entities like films or collections.
 type Sorting struct {
 	gorm.Model          // Embedded GORM model for database operations.
@@ -135,6 +152,60 @@ func (f *FilmFilters) String(filterType string) string {
 	}
 }
 
+// ResetAll resets all search filters to their default values.
+func (f *SearchFilters) ResetAll() {
+	*f = SearchFilters{}
+}
+
+// Reset resets a specific search filter.
+func (f *SearchFilters) Reset(field string) {
+	f.Apply(field, "")
+}
+
+// IsEnabled checks if any search filter is currently active.
+func (f *SearchFilters) IsEnabled() bool {
+	return *f != SearchFilters{}
+}
+
+// IsFieldEnabled checks if a specific search filter is active.
+func (f *SearchFilters) IsFieldEnabled(field string) bool {
+	return f.String(field) != ""
+}
+
+// Apply sets the value of a specific search filter.
+func (f *SearchFilters) Apply(field, value string) {
+	switch field {
+	case "year":
+		f.Year = value
+	case "filmType":
+		f.Type = value
+	case "genre":
+		f.Genre = value
+	case "country":
+		f.Country = value
+	case "minRating":
+		f.Rating = value
+	}
+}
+
+// String returns the value of a specific search filter.
+func (f *SearchFilters) String(field string) string {
+	switch field {
+	case "year":
+		return f.Year
+	case "filmType":
+		return f.Type
+	case "genre":
+		return f.Genre
+	case "country":
+		return f.Country
+	case "minRating":
+		return f.Rating
+	default:
+		return ""
+	}
+}
+
 // Clear resets all sorting fields to their default values.
 func (f *Sorting) Clear() {
 	f.Field = ""
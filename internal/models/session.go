@@ -19,6 +19,7 @@ type Session struct {
 	AccessToken           string                 `json:"access_token"`                // Access token for API authentication.
 	RefreshToken          string                 `json:"refresh_token"`               // Refresh token for API authentication.
 	KinopoiskAPIToken     string                 `json:"kinopoisk_api_token"`         // Kinopoisk API token for external API requests.
+	PendingKinopoiskToken string                 `json:"pending_kinopoisk_token"`     // Encrypted Kinopoisk API token awaiting confirmation.
 	State                 string                 // Current session state (e.g., awaiting input).
 	Context               string                 // Current session context (e.g., film, collection).
 	AdminState            *AdminState            `gorm:"foreignKey:SessionID"` // Admin-specific session state.
@@ -70,12 +71,18 @@ func (s *Session) ClearAllStates() {
 
 // Logout logs the user out by clearing tokens, user data, context, and all states.
 func (s *Session) Logout() {
-	s.AccessToken, s.RefreshToken, s.KinopoiskAPIToken = "", "", ""
+	s.AccessToken, s.RefreshToken, s.KinopoiskAPIToken, s.PendingKinopoiskToken = "", "", "", ""
 	s.ClearUser()
 	s.ClearContext()
 	s.ClearAllStates()
 }
 
+// IsKinopoiskSearch reports whether new films are searched on Kinopoisk, which requires the user's Kinopoisk token.
+// Only Kinopoisk searches can be refined with search filters.
+func (s *Session) IsKinopoiskSearch() bool {
+	return s.KinopoiskAPIToken != "" && !s.FilmsState.SearchAnime
+}
+
 // GetFilmFiltersByCtx retrieves the film filters based on the current session context.
 func (s *Session) GetFilmFiltersByCtx() *FilmFilters {
 	switch s.Context {
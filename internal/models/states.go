@@ -34,7 +34,11 @@ type FilmsState struct {
 	PageSize          int              `json:"-" gorm:"default:4"`                                        // Number of films per page.
 	CurrentPage       int              `json:"-"`                                                         // Current page number.
 	TotalRecords      int              `json:"-"`                                                         // Total number of films.
+	LoadedPage        int              `json:"-"`                                                         // Page of the films stored in the state.
+	LoadedFrom        string           `json:"-"`                                                         // Source of the films stored in the state (e.g., "film" or "collection:1").
 	Title             string           `json:"-"`                                                         // Search title for filtering films.
+	SearchAnime       bool             `json:"-"`                                                         // Whether new films are searched among anime.
+	SearchFilters     SearchFilters    `json:"-" gorm:"serializer:json"`                                  // Refinements of the search for new films on Kinopoisk.
 	FilmFilters       *FilmFilters     `gorm:"polymorphic:Filterable;polymorphicValue:FilmFilters"`       // Filters for films.
 	CollectionFilters *FilmFilters     `gorm:"polymorphic:Filterable;polymorphicValue:CollectionFilters"` // Filters for collections.
 	FilmSorting       *Sorting         `gorm:"polymorphic:Sortable;polymorphicValue:FilmSorting"`         // Sorting options for films.
@@ -63,12 +67,14 @@ type FilmDetailState struct {
 // CollectionsState represents the state for managing collections and their sorting.
 type CollectionsState struct {
 	BaseState
-	Collections []apiModels.Collection `json:"collections" gorm:"serializer:json"`            // List of collections in the current state.
-	LastPage    int                    `json:"-"`                                             // Last page number for pagination.
-	PageSize    int                    `json:"-" gorm:"default:4"`                            // Number of collections per page.
-	CurrentPage int                    `json:"-"`                                             // Current page number.
-	Name        string                 `json:"-"`                                             // Search name for filtering collections.
-	Sorting     *Sorting               `gorm:"polymorphic:Sortable;polymorphicValue:Sorting"` // Sorting options for collections.
+	Collections  []apiModels.Collection `json:"collections" gorm:"serializer:json"`            // List of collections in the current state.
+	LastPage     int                    `json:"-"`                                             // Last page number for pagination.
+	PageSize     int                    `json:"-" gorm:"default:4"`                            // Number of collections per page.
+	CurrentPage  int                    `json:"-"`                                             // Current page number.
+	TotalRecords int                    `json:"-"`                                             // Total number of collections.
+	LoadedPage   int                    `json:"-"`                                             // Page of the collections stored in the state.
+	Name         string                 `json:"-"`                                             // Search name for filtering collections.
+	Sorting      *Sorting               `gorm:"polymorphic:Sortable;polymorphicValue:Sorting"` // Sorting options for collections.
 }
 
 // CollectionDetailState represents the state for managing detailed information about a specific collection.
@@ -1,19 +1,19 @@
 // Package client provides utilities for making HTTP requests to external services.
 //
 // It includes functions for preparing, sending, and handling HTTP requests with customizable headers,
-// body, and expected status codes.
+// body, and expected status codes. Requests are sent through the shared clients of the httpclient
+// package, which apply per-service timeouts and retries, and carry the context of the update.
 //
 // The package also supports error handling and logging for failed requests.
 package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"github.com/k4sper1love/watchlist-bot/pkg/httpclient"
 	"net/http"
-	"strconv"
-	"strings"
 )
 
 const (
@@ -21,27 +21,29 @@ const (
 	HeaderVerification   = "Verification"     // Header for verification tokens.
 	HeaderExternalAPIKey = "X-API-KEY"        // Header for external API keys.
 	HeaderContentType    = "Content-Type"     // Header for specifying content type.
+	HeaderUserAgent      = "User-Agent"       // Header identifying the client, required by some external APIs.
 	ContentTypeJSON      = "application/json" // Default content type for JSON requests.
 )
 
 // CustomRequest represents a custom HTTP request with additional options.
 type CustomRequest struct {
-	HeaderType         string // Type of header to set (e.g., Authorization, X-API-KEY).
-	HeaderValue        string // Value for the specified header.
-	Method             string // HTTP method (e.g., GET, POST, PUT, DELETE).
-	URL                string // Target URL for the request.
-	Body               any    // Request body (optional).
-	ExpectedStatusCode int    // Expected HTTP status code for successful responses.
-	WithoutLog         bool   // Whether to suppress logging for failed responses.
-	TelegramID         int    // Unique identifier of the Telegram user or chat.
+	Context            context.Context // Context of the update; canceling it aborts the request (optional).
+	Service            string          // Service whose client settings are used (e.g., httpclient.Kinopoisk).
+	HeaderType         string          // Type of header to set (e.g., Authorization, X-API-KEY).
+	HeaderValue        string          // Value for the specified header.
+	Method             string          // HTTP method (e.g., GET, POST, PUT, DELETE).
+	URL                string          // Target URL for the request.
+	Body               any             // Request body (optional).
+	ExpectedStatusCode int             // Expected HTTP status code for successful responses.
+	WithoutLog         bool            // Whether to suppress logging for failed responses.
+	TelegramID         int             // Unique identifier of the Telegram user or chat.
 }
 
-// SendRequest sends an HTTP request and returns the response.
-func SendRequest(telegramID int, req *http.Request) (*http.Response, error) {
+// SendRequest sends an HTTP request using the client of the given service and returns the response.
+func SendRequest(telegramID int, service string, req *http.Request) (*http.Response, error) {
 	utils.LogRequestDebug(telegramID, req.Method, req.URL.String())
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := httpclient.For(service).Do(req)
 	if err != nil {
 		utils.LogRequestError(telegramID, "failed to send request", err, req.Method, req.URL.String())
 		return nil, err
@@ -51,15 +53,15 @@ func SendRequest(telegramID int, req *http.Request) (*http.Response, error) {
 }
 
 // SendRequestWithOptions sends an HTTP request with custom headers and body.
-func SendRequestWithOptions(telegramID int, url, method string, body any, headers map[string]string) (*http.Response, error) {
-	req, err := prepareRequest(url, method, body)
+func SendRequestWithOptions(ctx context.Context, telegramID int, service, url, method string, body any, headers map[string]string) (*http.Response, error) {
+	req, err := prepareRequest(ctx, url, method, body)
 	if err != nil {
 		utils.LogRequestError(telegramID, "failed to prepare request", err, method, url)
 		return nil, err
 	}
 
 	setRequestHeaders(req, headers)
-	return SendRequest(telegramID, req)
+	return SendRequest(telegramID, service, req)
 }
 
 // setRequestHeaders sets the headers for an HTTP request.
@@ -70,8 +72,8 @@ func setRequestHeaders(req *http.Request, headers map[string]string) {
 	}
 }
 
-// prepareRequest prepares an HTTP request with the given URL, method, and data.
-func prepareRequest(url, method string, data any) (*http.Request, error) {
+// prepareRequest prepares an HTTP request with the given context, URL, method, and data.
+func prepareRequest(ctx context.Context, url, method string, data any) (*http.Request, error) {
 	requestBody := &bytes.Buffer{}
 
 	if data != nil {
@@ -81,46 +83,29 @@ func prepareRequest(url, method string, data any) (*http.Request, error) {
 		}
 		requestBody = bytes.NewBuffer(body)
 	}
-	return http.NewRequest(method, url, requestBody)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return http.NewRequestWithContext(ctx, method, url, requestBody)
 }
 
 // Do sends a custom HTTP request and validates the response status code.
+// An unexpected status code is reported as an *APIError.
 func Do(req *CustomRequest) (*http.Response, error) {
 	headers := make(map[string]string)
 	if req.HeaderType != "" {
 		headers[req.HeaderType] = req.HeaderValue
 	}
 
-	resp, err := SendRequestWithOptions(req.TelegramID, req.URL, req.Method, req.Body, headers)
+	resp, err := SendRequestWithOptions(req.Context, req.TelegramID, req.Service, req.URL, req.Method, req.Body, headers)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode != req.ExpectedStatusCode {
-		defer utils.CloseBody(resp.Body)
-		if req.WithoutLog {
-			return nil, fmt.Errorf("failed response")
-		}
-		return nil, utils.LogResponseError(req.TelegramID, req.URL, req.Method, req.ExpectedStatusCode, resp.StatusCode, resp.Status)
+	if err = CheckResponse(req.TelegramID, resp, req.ExpectedStatusCode, req.WithoutLog); err != nil {
+		utils.CloseBody(resp.Body)
+		return nil, err
 	}
 
 	return resp, nil
 }
-
-// ParseErrorStatusCode extracts the HTTP status code from an error message.
-func ParseErrorStatusCode(err error) int {
-	if !strings.HasPrefix(err.Error(), "failed response") {
-		return -1
-	}
-
-	parts := strings.Split(err.Error(), "code")
-	if len(parts) < 2 {
-		return -1
-	}
-
-	code, err := strconv.Atoi(strings.TrimSpace(parts[len(parts)-1]))
-	if err != nil {
-		return -1
-	}
-	return code
-}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxBodySnippet is the maximum number of response body bytes kept in an APIError.
+const maxBodySnippet = 512
+
+// APIError describes a response whose status code differs from the expected one.
+// Use errors.As to inspect it.
+type APIError struct {
+	StatusCode int            // HTTP status code of the response.
+	Status     string         // HTTP status line of the response.
+	Method     string         // HTTP method of the request.
+	Endpoint   string         // Request URL with secrets redacted.
+	Body       string         // Beginning of the response body.
+	Payload    map[string]any // Error payload returned by the API, if the body is a JSON object.
+}
+
+// Error returns a description of the failed response.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("failed response from %s %s with code %d", e.Method, e.Endpoint, e.StatusCode)
+	if message := e.Message(); message != "" {
+		msg += ": " + message
+	}
+	return msg
+}
+
+// Message returns the error message from the API's error payload, or an empty string if there is none.
+func (e *APIError) Message() string {
+	for _, key := range []string{"error", "message", "Error", "detail"} {
+		if message, ok := e.Payload[key].(string); ok && message != "" {
+			return message
+		}
+	}
+	return ""
+}
+
+// NewAPIError creates an APIError from the response, reading the beginning of its body.
+func NewAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Endpoint = utils.RedactSecrets(resp.Request.URL.String())
+	}
+
+	if resp.Body != nil {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxBodySnippet))
+		apiErr.Body = utils.RedactSecrets(strings.ToValidUTF8(string(data), string(utf8.RuneError)))
+		_ = json.Unmarshal(data, &apiErr.Payload)
+	}
+
+	return apiErr
+}
+
+// CheckResponse returns an APIError if the response status code differs from the expected one.
+// The error is logged unless withoutLog is set.
+func CheckResponse(telegramID int, resp *http.Response, expectedStatusCode int, withoutLog bool) error {
+	if resp.StatusCode == expectedStatusCode {
+		return nil
+	}
+
+	apiErr := NewAPIError(resp)
+	if !withoutLog {
+		utils.LogResponseError(telegramID, apiErr.Method, apiErr.Endpoint, expectedStatusCode, apiErr.StatusCode, apiErr.Status, apiErr.Body)
+	}
+	return apiErr
+}
+
+// StatusCode returns the HTTP status code carried by an APIError in the error chain, or 0 if there is none.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,142 @@
+package parsing
+
+import (
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"strings"
+	"testing"
+)
+
+func TestParseAnimeFromShikimori(t *testing.T) {
+	session := &models.Session{Lang: "ru"}
+	parsed, err := parseAnimeFromShikimori(session, openFixture(t, "shikimori_anime.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	film := parsed.ToFilm(session)
+
+	if film.Title != "Ковбой Бибоп" {
+		t.Errorf("title = %q, want the Russian title", film.Title)
+	}
+	if film.Year != 1998 || film.Rating != 8.75 {
+		t.Errorf("year = %d, rating = %v, want 1998 and 8.75", film.Year, film.Rating)
+	}
+	if film.Genre != "Экшен, Фантастика" {
+		t.Errorf("genre = %q, want %q", film.Genre, "Экшен, Фантастика")
+	}
+	if film.ImageURL != "https://shikimori.one/system/animes/original/1.jpg?1674378220" {
+		t.Errorf("image URL = %q, want the poster", film.ImageURL)
+	}
+	if film.URL != "https://shikimori.one/animes/1" {
+		t.Errorf("URL = %q, want the anime page", film.URL)
+	}
+	if !strings.HasPrefix(film.Description, "Космический охотник за головами Спайк Шпигель путешествует") {
+		t.Errorf("description = %q, want the description without markup", film.Description)
+	}
+	if !strings.HasSuffix(film.Description, "\n📺 Эпизоды: 26") {
+		t.Errorf("description = %q, want the number of episodes", film.Description)
+	}
+	if !strings.Contains(film.Description, ": Cowboy Bebop, カウボーイビバップ\n") {
+		t.Errorf("description = %q, want the alternative titles without duplicates", film.Description)
+	}
+}
+
+func TestParseAnimeListFromShikimori(t *testing.T) {
+	films, hasNext, err := parseAnimeListFromShikimori(&models.Session{Lang: "en"}, openFixture(t, "shikimori_search.json"), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(films) != 2 || !hasNext {
+		t.Fatalf("got %d films, next page = %v, want 2 films and a next page", len(films), hasNext)
+	}
+	if films[0].Title != "Cowboy Bebop" {
+		t.Errorf("title = %q, want the romanized title for English users", films[0].Title)
+	}
+	if films[1].ImageURL != "" {
+		t.Errorf("image URL = %q, want none for a missing poster", films[1].ImageURL)
+	}
+	if !strings.Contains(films[1].Description, "Episodes: 1") {
+		t.Errorf("description = %q, want the number of episodes", films[1].Description)
+	}
+}
+
+func TestParseAnimeFromJikan(t *testing.T) {
+	session := &models.Session{Lang: "en"}
+	parsed, err := parseAnimeFromJikan(session, openFixture(t, "jikan_anime.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	film := parsed.ToFilm(session)
+
+	if film.Title != "Cowboy Bebop" || film.Year != 1998 || film.Rating != 8.75 {
+		t.Errorf("title = %q, year = %d, rating = %v, want Cowboy Bebop, 1998 and 8.75", film.Title, film.Year, film.Rating)
+	}
+	if film.Genre != "Action, Award Winning, Sci-Fi" {
+		t.Errorf("genre = %q, want all genres", film.Genre)
+	}
+	if film.ImageURL != "https://cdn.myanimelist.net/images/anime/4/19644l.jpg" {
+		t.Errorf("image URL = %q, want the large poster", film.ImageURL)
+	}
+	if film.URL != "https://myanimelist.net/anime/1" {
+		t.Errorf("URL = %q, want the anime page", film.URL)
+	}
+	if want := "Crime is timeless. By the year 2071, humanity has expanded across the galaxy.\n\n🔤 Alternative titles: カウボーイビバップ\n📺 Episodes: 26"; film.Description != want {
+		t.Errorf("description = %q, want %q", film.Description, want)
+	}
+}
+
+func TestParseAnimeListFromJikan(t *testing.T) {
+	films, total, err := parseAnimeListFromJikan(&models.Session{Lang: "en"}, openFixture(t, "jikan_search.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(films) != 2 || total != 6 {
+		t.Fatalf("got %d films of %d, want 2 of 6", len(films), total)
+	}
+	if films[1].Title != "Cowboy Bebop: The Movie" || films[1].Year != 2001 || films[1].Rating != 0 {
+		t.Errorf("title = %q, year = %d, rating = %v, want the English title, 2001 and no rating", films[1].Title, films[1].Year, films[1].Rating)
+	}
+}
+
+func TestToFilmKeepsMetadataWithinLimit(t *testing.T) {
+	parsed := &ParsedFilm{
+		Film:     apiModels.Film{Title: "Title", Description: strings.Repeat("a", 2*maxDescriptionLength)},
+		Metadata: FilmMetadata{Episodes: 12, AlternativeTitles: []string{"Other"}},
+	}
+	description := parsed.ToFilm(&models.Session{Lang: "en"}).Description
+
+	if n := len([]rune(description)); n > maxDescriptionLength {
+		t.Errorf("description has %d characters, want at most %d", n, maxDescriptionLength)
+	}
+	if !strings.HasSuffix(description, "🔤 Alternative titles: Other\n📺 Episodes: 12") {
+		t.Errorf("description = %q, want the details kept", description)
+	}
+}
+
+func TestAnimeCanonicalize(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://shikimori.one/animes/z1-cowboy-bebop", "https://shikimori.one/animes/1"},
+		{"shikimori.me/animes/5-cowboy-bebop-tengoku-no-tobira/characters", "https://shikimori.one/animes/5"},
+		{"https://myanimelist.net/anime/1/Cowboy_Bebop?q=bebop", "https://myanimelist.net/anime/1"},
+	}
+
+	for _, tt := range tests {
+		source, u, err := FindSource(tt.url)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.url, err)
+		}
+
+		canonical, err := source.Canonicalize(u)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.url, err)
+		}
+		if canonical.String() != tt.want {
+			t.Errorf("%s: canonical URL = %q, want %q", tt.url, canonical, tt.want)
+		}
+	}
+}
@@ -3,4 +3,14 @@
 //
 // It handles API requests, HTML/JSON parsing, URL extraction, and data transformation
 // into structured `models.Film` objects, ensuring reliable integration of external content.
+//
+// Every service is a FilmSource registered from its own file. GetFilmByURL picks the source by the URL host,
+// and the list of supported services shown to users is generated from the registry,
+// so adding a new site only takes a new file implementing FilmSource.
+// Links to other sites are parsed from their schema.org JSON-LD or OpenGraph tags.
+//
+// Sources return a ParsedFilm: the film together with metadata the film model has no fields for,
+// such as all genres, the director and the cast. ParsedFilm.ToFilm stores the genres in the genre field
+// and the rest as a block of labeled lines at the end of the description, which SplitDescription reads back.
+// The block also keeps the seasons of a series and the last watched episode, read and updated through Series.
 package parsing
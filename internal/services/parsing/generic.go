@@ -0,0 +1,211 @@
+package parsing
+
+import (
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/client"
+	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"github.com/k4sper1love/watchlist-bot/pkg/httpclient"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxGenericPageBytes is the maximum number of bytes of a page read when parsing a film from it.
+const maxGenericPageBytes = 5 << 20
+
+// genericUserAgent is the user agent of page requests, as many sites reject requests without a browser-like one.
+const genericUserAgent = "Mozilla/5.0 (compatible; watchlist-bot)"
+
+// genericTrackingParams lists the query parameters that only track visitors and are removed from page URLs.
+var genericTrackingParams = []string{"fbclid", "gclid", "yclid", "igshid", "si", "ref", "ref_"}
+
+// genericTitleYear matches a year in parentheses at the end of a page title, such as "The Matrix (1999 film)".
+var genericTitleYear = regexp.MustCompile(`\s*\((\d{4})(\s[^)]*)?\)\s*$`)
+
+// genericSource parses films from the pages of any site using their schema.org JSON-LD or OpenGraph tags.
+// It is not registered and is only used for URLs no other source serves, so the data it finds may be incomplete.
+type genericSource struct{}
+
+// Name returns the name of the generic source.
+func (genericSource) Name() string {
+	return "generic"
+}
+
+// MatchHost reports that any host may serve a page with film data.
+func (genericSource) MatchHost(string) bool {
+	return true
+}
+
+// ExtractID returns the page URL, as there are no film IDs on arbitrary sites.
+func (genericSource) ExtractID(u *url.URL) (string, error) {
+	return u.String(), nil
+}
+
+// Canonicalize returns the page URL without the fragment and tracking parameters.
+func (genericSource) Canonicalize(u *url.URL) (*url.URL, error) {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported URL scheme: %s", u.Scheme)
+	}
+
+	canonical := *u
+	canonical.Fragment = ""
+
+	query := canonical.Query()
+	for key := range query {
+		if strings.HasPrefix(key, "utm_") {
+			query.Del(key)
+		}
+	}
+	for _, key := range genericTrackingParams {
+		query.Del(key)
+	}
+	canonical.RawQuery = query.Encode()
+
+	return &canonical, nil
+}
+
+// Fetch fetches the page and parses the film from its JSON-LD or OpenGraph tags.
+// Pages are only fetched from public addresses, and only the beginning of large pages is parsed.
+func (genericSource) Fetch(app models.App, session *models.Session, u *url.URL) (*ParsedFilm, error) {
+	resp, err := client.Do(
+		&client.CustomRequest{
+			Context:            app.Context(),
+			Service:            httpclient.Public,      // Users may enter any URL, including those of internal hosts.
+			HeaderType:         client.HeaderUserAgent, // Some sites only serve pages to browsers.
+			HeaderValue:        genericUserAgent,
+			Method:             http.MethodGet, // HTTP GET method for fetching data.
+			URL:                u.String(),
+			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
+			TelegramID:         session.TelegramID,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
+
+	film, err := parseFilmFromPage(io.LimitReader(resp.Body, maxGenericPageBytes), resp.Request.URL)
+	if err != nil {
+		utils.LogParseFromURLError(session.TelegramID, "failed to find film data", err, resp.Request.URL.String())
+		return nil, err
+	}
+	return film, nil
+}
+
+// parseFilmFromPage parses film details and metadata from a schema.org Movie, TVSeries or VideoObject in the JSON-LD
+// of the page, completing them with its OpenGraph and meta tags. Relative image URLs are resolved against the page URL.
+func parseFilmFromPage(data io.Reader, page *url.URL) (*ParsedFilm, error) {
+	doc, err := goquery.NewDocumentFromReader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := &ParsedFilm{}
+	film := &parsed.Film
+	if object := findJSONLD(doc, "Movie", "TVSeries", "VideoObject"); object != nil {
+		film.Title = getJSONLDString(object, "name", "")
+		film.Year = getJSONLDYear(object)
+		film.Description = getJSONLDString(object, "description", "")
+		film.Rating = getJSONLDRating(object)
+		film.ImageURL = firstNonEmpty(getJSONLDImage(object), getJSONLDString(object, "thumbnailUrl", ""))
+		parsed.Metadata = getJSONLDMetadata(object)
+	}
+
+	title := getPageTitle(doc, page)
+	if film.Title == "" {
+		film.Title = genericTitleYear.ReplaceAllString(title, "")
+	}
+	if film.Title == "" {
+		return nil, fmt.Errorf("film data not found on the page")
+	}
+
+	if film.Year == 0 {
+		if match := genericTitleYear.FindStringSubmatch(title); match != nil {
+			film.Year, _ = strconv.Atoi(match[1])
+		} else {
+			film.Year = parseYearFromDate(firstNonEmpty(getMetaContent(doc, "video:release_date"), getMetaContent(doc, "og:video:release_date")))
+		}
+	}
+	if film.Description == "" {
+		film.Description = firstNonEmpty(getMetaContent(doc, "og:description"), getMetaContent(doc, "description"))
+	}
+	if film.ImageURL == "" {
+		film.ImageURL = firstNonEmpty(getMetaContent(doc, "og:image"), getMetaContent(doc, "twitter:image"))
+	}
+
+	film.ImageURL = resolvePageImageURL(page, film.ImageURL)
+	return parsed, nil
+}
+
+// getPageTitle returns the OpenGraph title of the page or its title tag without the site name,
+// such as "The Matrix" in "The Matrix - Wikipedia" on en.wikipedia.org.
+func getPageTitle(doc *goquery.Document, page *url.URL) string {
+	title := firstNonEmpty(getMetaContent(doc, "og:title"), strings.TrimSpace(doc.Find("title").First().Text()))
+
+	for _, separator := range []string{" - ", " — ", " – ", " | ", " · "} {
+		index := strings.LastIndex(title, separator)
+		if index > 0 && isSiteName(doc, page, title[index+len(separator):]) {
+			return strings.TrimSpace(title[:index])
+		}
+	}
+	return title
+}
+
+// isSiteName reports whether the name is the OpenGraph site name of the page or the name of its domain.
+func isSiteName(doc *goquery.Document, page *url.URL, name string) bool {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == strings.ToLower(getMetaContent(doc, "og:site_name")) {
+		return true
+	}
+
+	labels := strings.Split(strings.ToLower(page.Hostname()), ".")
+	return len(labels) >= 2 && strings.ReplaceAll(name, " ", "") == labels[len(labels)-2]
+}
+
+// getJSONLDYear returns the release year of a JSON-LD object from the first date property it has.
+func getJSONLDYear(object map[string]interface{}) int {
+	for _, key := range []string{"datePublished", "dateCreated", "startDate", "uploadDate"} {
+		if year := parseYearFromDate(getJSONLDString(object, key, "")); year != 0 {
+			return year
+		}
+	}
+	return 0
+}
+
+// resolvePageURL resolves a URL found on the page against the page URL; invalid URLs are dropped.
+func resolvePageURL(page *url.URL, rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return page.ResolveReference(u).String()
+}
+
+// resolvePageImageURL resolves the image URL found on the page like resolvePageURL.
+// Images that are not served over HTTP or point to a non-public address are dropped, since the page chooses the URL;
+// host names are checked again when the image is downloaded.
+func resolvePageImageURL(page *url.URL, rawURL string) string {
+	resolved := resolvePageURL(page, rawURL)
+	if resolved == "" {
+		return ""
+	}
+
+	u, err := url.Parse(resolved)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || strings.EqualFold(u.Hostname(), "localhost") {
+		return ""
+	}
+	if ip, err := netip.ParseAddr(u.Hostname()); err == nil && !httpclient.IsPublicIP(ip) {
+		return ""
+	}
+	return resolved
+}
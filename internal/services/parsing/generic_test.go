@@ -0,0 +1,118 @@
+package parsing
+
+import (
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// parsePageFixture parses a film from an HTML fixture as if it were served from the page URL
+// and returns it as it is stored for an English-speaking user.
+func parsePageFixture(t *testing.T, name, pageURL string) *apiModels.Film {
+	t.Helper()
+
+	page, err := url.Parse(pageURL)
+	if err != nil {
+		t.Fatalf("invalid page URL: %v", err)
+	}
+
+	parsed, err := parseFilmFromPage(openFixture(t, name), page)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return parsed.ToFilm(&models.Session{Lang: "en"})
+}
+
+func TestParseFilmFromPageWithJSONLD(t *testing.T) {
+	film := parsePageFixture(t, "generic_series.html", "https://streamplus.example/series/severance")
+
+	if film.Title != "Severance" || film.Year != 2022 {
+		t.Errorf("title = %q, year = %d, want Severance and 2022", film.Title, film.Year)
+	}
+	if film.Genre != "Drama, Mystery, Science Fiction" {
+		t.Errorf("genre = %q, want all genres", film.Genre)
+	}
+	if film.Rating != 8.8 {
+		t.Errorf("rating = %v, want 8.8", film.Rating)
+	}
+	if film.ImageURL != "https://streamplus.example/images/severance-poster.jpg" {
+		t.Errorf("image URL = %q, want the resolved poster", film.ImageURL)
+	}
+	if !strings.HasPrefix(film.Description, "Mark leads a team") {
+		t.Errorf("description = %q, want the JSON-LD description", film.Description)
+	}
+}
+
+func TestParseFilmFromPageWithOpenGraph(t *testing.T) {
+	film := parsePageFixture(t, "generic_wikipedia.html", "https://en.wikipedia.org/wiki/The_Matrix")
+
+	if film.Title != "The Matrix" {
+		t.Errorf("title = %q, want the title without the site name", film.Title)
+	}
+	if film.Year != 0 || film.Rating != 0 || film.Genre != "" {
+		t.Errorf("year = %d, rating = %v, genre = %q, want none", film.Year, film.Rating, film.Genre)
+	}
+	if !strings.HasSuffix(film.ImageURL, "1200px-The_Matrix.png") {
+		t.Errorf("image URL = %q, want the OpenGraph image", film.ImageURL)
+	}
+}
+
+func TestParseFilmFromPageWithMetaTags(t *testing.T) {
+	film := parsePageFixture(t, "generic_cinema.html", "https://cinemacity.example/films/dune-part-two")
+
+	if film.Title != "Dune: Part Two" || film.Year != 2024 {
+		t.Errorf("title = %q, year = %d, want Dune: Part Two and 2024", film.Title, film.Year)
+	}
+	if film.Description != "Paul Atreides unites with Chani and the Fremen." {
+		t.Errorf("description = %q, want the meta description", film.Description)
+	}
+	if film.ImageURL != "https://cinemacity.example/posters/dune-part-two.jpg" {
+		t.Errorf("image URL = %q, want the resolved image", film.ImageURL)
+	}
+}
+
+func TestParseFilmFromPageDropsNonPublicImages(t *testing.T) {
+	page, _ := url.Parse("https://example.com/films/1")
+
+	for _, image := range []string{"http://169.254.169.254/latest/meta-data/", "http://localhost:8080/poster.jpg", "http://[::1]/poster.jpg", "file:///etc/passwd"} {
+		html := `<html><head><title>Heat</title><meta property="og:image" content="` + image + `"></head></html>`
+		parsed, err := parseFilmFromPage(strings.NewReader(html), page)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", image, err)
+		}
+		if parsed.Film.ImageURL != "" {
+			t.Errorf("image URL = %q, want it dropped", parsed.Film.ImageURL)
+		}
+	}
+}
+
+func TestParseFilmFromPageWithoutTitle(t *testing.T) {
+	page, _ := url.Parse("https://example.com/")
+	if _, err := parseFilmFromPage(strings.NewReader("<html><body></body></html>"), page); err == nil {
+		t.Fatal("expected an error for a page without a title")
+	}
+}
+
+func TestGenericSourceFallback(t *testing.T) {
+	source, u, err := FindSource("example.com/films/1?utm_source=share&id=1#reviews")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if IsSupported(u.String()) {
+		t.Errorf("%s: want an unsupported site", u)
+	}
+
+	canonical, err := source.Canonicalize(u)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if canonical.String() != "https://example.com/films/1?id=1" {
+		t.Errorf("canonical URL = %q, want it without tracking parameters and the fragment", canonical)
+	}
+
+	if !IsSupported("https://letterboxd.com/film/the-matrix/") {
+		t.Error("want Letterboxd to be supported")
+	}
+}
@@ -3,27 +3,98 @@ package parsing
 import (
 	"encoding/json"
 	"fmt"
-	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/PuerkitoBio/goquery"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
 	"github.com/k4sper1love/watchlist-bot/internal/services/client"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"github.com/k4sper1love/watchlist-bot/pkg/httpclient"
+	"html"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strings"
 )
 
-// GetFilmFromIMDB fetches film details from the IMDB API using the provided URL.
-// It extracts the film ID from the URL, makes an HTTP request to the OMDB API,
-// and parses the response into an `models.Film` object.
-func GetFilmFromIMDB(app models.App, session *models.Session, url string) (*apiModels.Film, error) {
-	id, err := parseIDFromIMDB(url)
+// imdbName is the name of the IMDb source.
+const imdbName = "imdb"
+
+// imdbUserAgent is the user agent of title page requests, as IMDb only serves them to browsers.
+const imdbUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0 Safari/537.36"
+
+// imdbIDPattern matches an IMDb title ID, such as "tt0133093".
+var imdbIDPattern = regexp.MustCompile(`^tt\d{7,}$`)
+
+// imdbSource parses films from IMDb pages through the OMDb API or, without an OMDb key, from the title page itself.
+type imdbSource struct{}
+
+func init() {
+	Register(imdbSource{})
+}
+
+// Name returns the name of the IMDb source.
+func (imdbSource) Name() string {
+	return imdbName
+}
+
+// MatchHost reports whether the host belongs to IMDb, including its mobile version and former local domains.
+func (imdbSource) MatchHost(host string) bool {
+	return matchDomain(host, "imdb.com", "imdb.de", "imdb.fr", "imdb.it", "imdb.es", "imdb.pt")
+}
+
+// ExtractID extracts the IMDb ID ("tt" followed by digits) from the title page URL,
+// which may have a language prefix, such as "/de/title/tt0133093/".
+func (imdbSource) ExtractID(u *url.URL) (string, error) {
+	segments := pathSegments(u)
+	for i, segment := range segments {
+		if segment == "title" && i+1 < len(segments) && imdbIDPattern.MatchString(segments[i+1]) {
+			return segments[i+1], nil // The segment after "title" is the IMDb ID.
+		}
+	}
+	return "", fmt.Errorf("id not found") // Return an error if the ID cannot be extracted.
+}
+
+// Canonicalize returns the URL of the title page on the main IMDb site.
+func (s imdbSource) Canonicalize(u *url.URL) (*url.URL, error) {
+	id, err := s.ExtractID(u)
 	if err != nil {
-		utils.LogParseFromURLError(session.TelegramID, "failed to parse ID", err, url)
 		return nil, err
 	}
+	return url.Parse(fmt.Sprintf("https://www.imdb.com/title/%s/", id))
+}
+
+// Fetch fetches film details by the IMDb ID from the URL.
+// The OMDb API is used if its key is configured; if there is no key or the request fails,
+// the details are parsed from the IMDb title page.
+func (s imdbSource) Fetch(app models.App, session *models.Session, u *url.URL) (*ParsedFilm, error) {
+	id, err := s.ExtractID(u)
+	if err != nil {
+		utils.LogParseFromURLError(session.TelegramID, "failed to parse ID", err, u.String())
+		return nil, err
+	}
+
+	if app.Config.IMDBAPIToken != "" {
+		film, err := getFilmFromOMDb(app, session, id)
+		if err == nil {
+			return film, nil
+		}
+		slog.Warn(
+			"failed to get film from OMDb, parsing the IMDb page",
+			slog.String("error", utils.RedactSecrets(err.Error())),
+			slog.String("imdb_id", id),
+			slog.Int("telegram_id", session.TelegramID))
+	}
 
+	return getFilmFromIMDbPage(app, session, u)
+}
+
+// getFilmFromOMDb fetches film details from the OMDb API by the IMDb ID.
+func getFilmFromOMDb(app models.App, session *models.Session, id string) (*ParsedFilm, error) {
 	resp, err := client.Do(
 		&client.CustomRequest{
+			Context:            app.Context(),
+			Service:            httpclient.External,
 			Method:             http.MethodGet, // HTTP GET method for fetching data.
 			URL:                fmt.Sprintf("http://www.omdbapi.com/?apikey=%s&i=%s&plot=full", app.Config.IMDBAPIToken, id),
 			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
@@ -35,7 +106,7 @@ func GetFilmFromIMDB(app models.App, session *models.Session, url string) (*apiM
 	}
 	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
 
-	var film apiModels.Film
+	var film ParsedFilm
 	if err = parseFilmFromIMDB(&film, resp.Body); err != nil {
 		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
 		return nil, err
@@ -44,42 +115,203 @@ func GetFilmFromIMDB(app models.App, session *models.Session, url string) (*apiM
 	return &film, nil
 }
 
-// parseFilmFromIMDB parses film details from the IMDB API response into an `models.Film` object.
-func parseFilmFromIMDB(dest *apiModels.Film, data io.Reader) error {
+// getFilmFromIMDbPage fetches the IMDb title page and parses the film details from it.
+func getFilmFromIMDbPage(app models.App, session *models.Session, u *url.URL) (*ParsedFilm, error) {
+	resp, err := client.Do(
+		&client.CustomRequest{
+			Context:            app.Context(),
+			Service:            httpclient.External,
+			HeaderType:         client.HeaderUserAgent, // IMDb rejects requests from clients that are not browsers.
+			HeaderValue:        imdbUserAgent,
+			Method:             http.MethodGet, // HTTP GET method for fetching data.
+			URL:                u.String(),
+			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
+			TelegramID:         session.TelegramID,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
+
+	film, err := parseFilmFromIMDbPage(resp.Body)
+	if err != nil {
+		utils.LogParseFromURLError(session.TelegramID, "failed to find film data", err, resp.Request.URL.String())
+		return nil, err
+	}
+	return film, nil
+}
+
+// parseFilmFromIMDB parses film details and metadata from the OMDb API response into a `ParsedFilm` object.
+// OMDb reports errors, such as an exhausted quota, in the response body with a 200 status.
+func parseFilmFromIMDB(dest *ParsedFilm, data io.Reader) error {
 	var response map[string]interface{} // Temporary map to hold the JSON response.
 	if err := json.NewDecoder(data).Decode(&response); err != nil {
 		return err
 	}
 
+	if getStringFromMap(response, "Response", "True") == "False" {
+		return fmt.Errorf("OMDb error: %s", getStringFromMap(response, "Error", "unknown error"))
+	}
+
+	// OMDb marks missing values as "N/A".
+	for key, value := range response {
+		if value == "N/A" {
+			delete(response, key)
+		}
+	}
+
 	// Extract film details from the response.
-	dest.Title = getStringFromMap(response, "Title", "Unknown")      // Default title is "Unknown".
-	dest.Year = getIntFromStringMap(response, "Year", 0)             // Default year is 0.
-	dest.Genre = getFirstGenreFromString(response, "Genre", "")      // Extract the first genre from the list.
-	dest.Description = getStringFromMap(response, "Plot", "")        // Default description is an empty string.
-	dest.Rating = getFloatFromStringMap(response, "imdbRating", 0.0) // Default rating is 0.0.
-	dest.ImageURL = getStringFromMap(response, "Poster", "")         // Default image URL is an empty string.
+	dest.Film.Title = getStringFromMap(response, "Title", "Unknown")      // Default title is "Unknown".
+	dest.Film.Year = getIntFromStringMap(response, "Year", 0)             // Default year is 0.
+	dest.Film.Description = getStringFromMap(response, "Plot", "")        // Default description is an empty string.
+	dest.Film.Rating = getFloatFromStringMap(response, "imdbRating", 0.0) // Default rating is 0.0.
+	dest.Film.ImageURL = getStringFromMap(response, "Poster", "")         // Default image URL is an empty string.
+
+	dest.Metadata = FilmMetadata{
+		Genres:    getListFromString(response, "Genre"),
+		Runtime:   parseRuntime(getStringFromMap(response, "Runtime", "")),
+		Countries: getListFromString(response, "Country"),
+		Directors: getListFromString(response, "Director"),
+		Cast:      getListFromString(response, "Actors"),
+		AgeRating: getStringFromMap(response, "Rated", ""),
+	}
 
 	return nil
 }
 
-// parseIDFromIMDB extracts the IMDB ID from the given URL.
-func parseIDFromIMDB(url string) (string, error) {
-	shortURL := strings.TrimPrefix(url, "https://www.imdb.com/") // Remove the base URL.
-	parts := strings.Split(shortURL, "/")                        // Split the remaining path by "/".
-	if len(parts) > 1 {
-		return parts[1], nil // The second part of the path is the IMDB ID.
+// parseFilmFromIMDbPage parses film details and metadata from the JSON-LD of the IMDb title page
+// and completes them with the `__NEXT_DATA__` payload the page is rendered from.
+func parseFilmFromIMDbPage(data io.Reader) (*ParsedFilm, error) {
+	doc, err := goquery.NewDocumentFromReader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := &ParsedFilm{}
+	if object := findJSONLD(doc, "Movie", "TVSeries", "TVMiniSeries", "TVMovie", "TVEpisode", "TVSpecial", "VideoGame"); object != nil {
+		parsed.Film.Title = html.UnescapeString(getJSONLDString(object, "name", ""))
+		parsed.Film.Year = getJSONLDYear(object)
+		parsed.Film.Description = html.UnescapeString(getJSONLDString(object, "description", ""))
+		parsed.Film.Rating = getJSONLDRating(object)
+		parsed.Film.ImageURL = getJSONLDImage(object)
+		parsed.Metadata = getJSONLDMetadata(object)
+	}
+
+	if next := getIMDbNextData(doc); next != nil {
+		completeFilmFromIMDbNextData(parsed, next)
+	}
+
+	if parsed.Film.Title == "" {
+		return nil, fmt.Errorf("film data not found on the page")
+	}
+	return parsed, nil
+}
+
+// getIMDbNextData returns the page data of the `__NEXT_DATA__` payload of the IMDb title page, or nil if there is none.
+func getIMDbNextData(doc *goquery.Document) map[string]interface{} {
+	var payload map[string]interface{}
+	if err := json.Unmarshal([]byte(doc.Find("script#__NEXT_DATA__").First().Text()), &payload); err != nil {
+		return nil
+	}
+
+	data, _ := getPath(payload, "props", "pageProps").(map[string]interface{})
+	return data
+}
+
+// completeFilmFromIMDbNextData fills in the film details missing from the JSON-LD with the `__NEXT_DATA__` page data.
+// The release year and the full plot are preferred, as the JSON-LD has the local release date and a shortened plot.
+func completeFilmFromIMDbNextData(parsed *ParsedFilm, data map[string]interface{}) {
+	film, metadata := &parsed.Film, &parsed.Metadata
+	title, _ := data["aboveTheFoldData"].(map[string]interface{})
+
+	if film.Title == "" {
+		film.Title = firstNonEmpty(getPathString(title, "titleText", "text"), getPathString(title, "originalTitleText", "text"))
+	}
+	if year, ok := getPath(title, "releaseYear", "year").(float64); ok {
+		film.Year = int(year)
+	}
+	if plot := getPathString(title, "plot", "plotText", "plainText"); plot != "" {
+		film.Description = plot
+	}
+	if film.Rating == 0 {
+		if rating, ok := getPath(title, "ratingsSummary", "aggregateRating").(float64); ok {
+			film.Rating = utils.Round(rating)
+		}
+	}
+	if film.ImageURL == "" {
+		film.ImageURL = getPathString(title, "primaryImage", "url")
+	}
+
+	if metadata.OriginalTitle == "" {
+		metadata.OriginalTitle = getPathString(title, "originalTitleText", "text")
+	}
+	if len(metadata.Genres) == 0 {
+		metadata.Genres = getTextsFromPath(title, "genres", "genres")
+	}
+	if seconds, ok := getPath(title, "runtime", "seconds").(float64); ok && metadata.Runtime == 0 {
+		metadata.Runtime = int(seconds) / 60
+	}
+	if len(metadata.Countries) == 0 {
+		metadata.Countries = getTextsFromPath(data, "mainColumnData", "countriesOfOrigin", "countries")
+	}
+	if metadata.AgeRating == "" {
+		metadata.AgeRating = getPathString(title, "certificate", "rating")
 	}
-	return "", fmt.Errorf("id not found") // Return an error if the ID cannot be extracted.
 }
 
-// getFirstGenreFromString extracts the first genre from a comma-separated string in the map.
-// If the key exists and contains genres, it splits the string and returns the first genre.
-// Otherwise, it returns the default value.
-func getFirstGenreFromString(data map[string]interface{}, key string, defaultValue string) string {
-	if value, ok := data[key].(string); ok {
-		if genres := strings.Split(value, ","); len(genres) > 0 {
-			return strings.TrimSpace(genres[0]) // Return the first genre after trimming whitespace.
+// getTextsFromPath returns the "text" values of the objects in the list at the path of keys, such as IMDb genres.
+func getTextsFromPath(data map[string]interface{}, keys ...string) []string {
+	items, _ := getPath(data, keys...).([]interface{})
+
+	var texts []string
+	for _, item := range items {
+		if object, ok := item.(map[string]interface{}); ok {
+			if text := getStringFromMap(object, "text", ""); text != "" {
+				texts = append(texts, text)
+			}
+		}
+	}
+	return texts
+}
+
+// getPath returns the value at the path of keys in nested JSON objects, or nil if there is none.
+func getPath(data map[string]interface{}, keys ...string) interface{} {
+	var value interface{} = data
+	for _, key := range keys {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
+
+// getPathString returns the string at the path of keys in nested JSON objects, or an empty string if there is none.
+func getPathString(data map[string]interface{}, keys ...string) string {
+	value, _ := getPath(data, keys...).(string)
+	return strings.TrimSpace(value)
+}
+
+// expandIMDbID turns an IMDb ID sent on its own, such as "tt0133093", into the URL of its title page.
+// Other input is returned unchanged.
+func expandIMDbID(rawURL string) string {
+	if id := strings.TrimSpace(rawURL); imdbIDPattern.MatchString(id) {
+		return fmt.Sprintf("https://www.imdb.com/title/%s/", id)
+	}
+	return rawURL
+}
+
+// getListFromString extracts the values of a comma-separated string in the map, such as the genres in an OMDb response.
+func getListFromString(data map[string]interface{}, key string) []string {
+	value, _ := data[key].(string)
+
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
 		}
 	}
-	return defaultValue // Return the default value if no genres are found.
+	return values
 }
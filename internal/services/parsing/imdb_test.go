@@ -0,0 +1,108 @@
+package parsing
+
+import (
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"strings"
+	"testing"
+)
+
+// parseIMDbFixture parses a film from an IMDb title page fixture and returns it with its metadata
+// and as it is stored for an English-speaking user.
+func parseIMDbFixture(t *testing.T, name string) (*apiModels.Film, FilmMetadata) {
+	t.Helper()
+
+	parsed, err := parseFilmFromIMDbPage(openFixture(t, name))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return parsed.ToFilm(&models.Session{Lang: "en"}), parsed.Metadata
+}
+
+func TestParseFilmFromIMDbPage(t *testing.T) {
+	film, metadata := parseIMDbFixture(t, "imdb_title.html")
+
+	if film.Title != "Schindler's List" {
+		t.Errorf("title = %q, want the unescaped title", film.Title)
+	}
+	if film.Year != 1993 || film.Rating != 9 {
+		t.Errorf("year = %d, rating = %v, want the release year 1993 and 9", film.Year, film.Rating)
+	}
+	if film.Genre != "Biography, Drama, History" {
+		t.Errorf("genre = %q, want all genres", film.Genre)
+	}
+	if !strings.HasPrefix(film.Description, "In German-occupied Poland") {
+		t.Errorf("description = %q, want the plot", film.Description)
+	}
+	if !strings.HasSuffix(film.Description, "\n\n🎬 Director: Steven Spielberg\n🔞 Age rating: R") {
+		t.Errorf("description = %q, want the director and the age rating", film.Description)
+	}
+	if metadata.OriginalTitle != "Schindler's List" || len(metadata.Directors) != 1 {
+		t.Errorf("metadata = %+v, want the original title and the director", metadata)
+	}
+	if film.ImageURL != "https://m.media-amazon.com/images/M/MV5BNjM1ZDQxYWUt.jpg" {
+		t.Errorf("image URL = %q, want the poster", film.ImageURL)
+	}
+}
+
+func TestParseFilmFromIMDbPageWithoutJSONLD(t *testing.T) {
+	film, metadata := parseIMDbFixture(t, "imdb_next_data.html")
+
+	if film.Title != "Der Untergang" || film.Year != 2004 || film.Rating != 8.2 {
+		t.Errorf("title = %q, year = %d, rating = %v, want Der Untergang, 2004 and 8.2", film.Title, film.Year, film.Rating)
+	}
+	if film.Genre != "Biografie, Drama, Kriegsfilm" {
+		t.Errorf("genre = %q, want all genres", film.Genre)
+	}
+	if !strings.HasPrefix(film.Description, "Traudl Junge") || film.ImageURL == "" {
+		t.Errorf("description = %q, image URL = %q, want both", film.Description, film.ImageURL)
+	}
+	if metadata.Runtime != 156 || metadata.AgeRating != "12" || strings.Join(metadata.Countries, ", ") != "Deutschland, Österreich, Italien" {
+		t.Errorf("metadata = %+v, want the runtime, age rating and countries", metadata)
+	}
+}
+
+func TestParseFilmFromIMDbPageWithoutFilmData(t *testing.T) {
+	if _, err := parseFilmFromIMDbPage(strings.NewReader("<html></html>")); err == nil {
+		t.Fatal("expected an error for a page without film data")
+	}
+}
+
+func TestParseFilmFromOMDbError(t *testing.T) {
+	var film ParsedFilm
+	if err := parseFilmFromIMDB(&film, strings.NewReader(`{"Response":"False","Error":"Request limit reached!"}`)); err == nil {
+		t.Fatal("expected an error for an OMDb error response")
+	}
+}
+
+func TestIMDbCanonicalize(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://www.imdb.com/title/tt0133093/?ref_=nv_sr_srsg_0", "https://www.imdb.com/title/tt0133093/"},
+		{"https://m.imdb.com/title/tt0133093/", "https://www.imdb.com/title/tt0133093/"},
+		{"https://www.imdb.com/de/title/tt0133093/reviews", "https://www.imdb.com/title/tt0133093/"},
+		{"imdb.de/title/tt0133093", "https://www.imdb.com/title/tt0133093/"},
+		{" tt0133093 ", "https://www.imdb.com/title/tt0133093/"},
+		{"tt10872600", "https://www.imdb.com/title/tt10872600/"},
+	}
+
+	for _, tt := range tests {
+		source, u, err := FindSource(tt.url)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.url, err)
+		}
+		if source.Name() != imdbName {
+			t.Fatalf("%s: source = %q, want imdb", tt.url, source.Name())
+		}
+
+		canonical, err := source.Canonicalize(u)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.url, err)
+		}
+		if canonical.String() != tt.want {
+			t.Errorf("%s: canonical URL = %q, want %q", tt.url, canonical, tt.want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package parsing
+
+import (
+	"encoding/json"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// findJSONLD returns the first schema.org object of one of the types embedded in the page
+// as a JSON-LD script, or nil if there is none.
+// Objects nested in arrays and "@graph" lists are searched too.
+func findJSONLD(doc *goquery.Document, types ...string) map[string]interface{} {
+	var found map[string]interface{}
+
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		var data interface{}
+		if err := json.Unmarshal([]byte(stripCDATA(s.Text())), &data); err != nil {
+			return true // Skip malformed blocks and keep looking.
+		}
+		found = findJSONLDObject(data, types)
+		return found == nil
+	})
+
+	return found
+}
+
+// findJSONLDObject searches the decoded JSON-LD data for an object of one of the types.
+func findJSONLDObject(data interface{}, types []string) map[string]interface{} {
+	switch value := data.(type) {
+	case []interface{}:
+		for _, item := range value {
+			if object := findJSONLDObject(item, types); object != nil {
+				return object
+			}
+		}
+
+	case map[string]interface{}:
+		for _, objectType := range getJSONLDStrings(value, "@type") {
+			for _, t := range types {
+				if objectType == t {
+					return value
+				}
+			}
+		}
+		if graph, ok := value["@graph"]; ok {
+			return findJSONLDObject(graph, types)
+		}
+	}
+	return nil
+}
+
+// stripCDATA removes the CDATA markers some sites wrap their JSON-LD scripts in.
+func stripCDATA(text string) string {
+	text = strings.TrimSpace(text)
+	for _, marker := range []string{"/* <![CDATA[ */", "/* ]]> */", "<![CDATA[", "]]>"} {
+		text = strings.ReplaceAll(text, marker, "")
+	}
+	return text
+}
+
+// getJSONLDStrings returns the values of a JSON-LD property that may hold a string, an object with a name
+// or a list of them, such as "genre" or "director".
+func getJSONLDStrings(data map[string]interface{}, key string) []string {
+	var values []string
+
+	var collect func(value interface{})
+	collect = func(value interface{}) {
+		switch v := value.(type) {
+		case string:
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		case map[string]interface{}:
+			collect(v["name"])
+		case []interface{}:
+			for _, item := range v {
+				collect(item)
+			}
+		}
+	}
+
+	collect(data[key])
+	return values
+}
+
+// getJSONLDString returns the first value of a JSON-LD property, or the default value if there is none.
+func getJSONLDString(data map[string]interface{}, key, defaultValue string) string {
+	if values := getJSONLDStrings(data, key); len(values) > 0 {
+		return values[0]
+	}
+	return defaultValue
+}
+
+// getJSONLDMetadata returns the metadata of a JSON-LD object: its genres, runtime, countries, directors,
+// actors and age rating. Actors are read from both "actor" and the legacy "actors" property. HTML entities in the names are unescaped, as some sites, such as IMDb, escape them.
+func getJSONLDMetadata(data map[string]interface{}) FilmMetadata {
+	unescape := func(values []string) []string {
+		for i, value := range values {
+			values[i] = html.UnescapeString(value)
+		}
+		return values
+	}
+
+	return FilmMetadata{
+		OriginalTitle: html.UnescapeString(getJSONLDString(data, "alternateName", "")),
+		Genres:        unescape(getJSONLDStrings(data, "genre")),
+		Runtime:       parseRuntime(getJSONLDString(data, "duration", "")),
+		Countries:     unescape(getJSONLDStrings(data, "countryOfOrigin")),
+		Directors:     unescape(getJSONLDStrings(data, "director")),
+		Cast:          unescape(append(getJSONLDStrings(data, "actor"), getJSONLDStrings(data, "actors")...)),
+		AgeRating:     getJSONLDString(data, "contentRating", ""),
+	}
+}
+
+// getJSONLDImage returns the URL of the image of a JSON-LD object,
+// which may be given as a URL, an ImageObject or a list of them.
+func getJSONLDImage(data map[string]interface{}) string {
+	switch image := data["image"].(type) {
+	case string:
+		return image
+	case map[string]interface{}:
+		return getStringFromMap(image, "url", "")
+	case []interface{}:
+		if len(image) > 0 {
+			return getJSONLDImage(map[string]interface{}{"image": image[0]})
+		}
+	}
+	return ""
+}
+
+// getJSONLDRating returns the average rating of a JSON-LD object scaled to a 10-point scale.
+// It returns 0 if the object has no rating.
+func getJSONLDRating(data map[string]interface{}) float64 {
+	rating, ok := data["aggregateRating"].(map[string]interface{})
+	if !ok {
+		return 0
+	}
+
+	value := getJSONLDNumber(rating, "ratingValue")
+	best := getJSONLDNumber(rating, "bestRating")
+	if best <= 0 {
+		best = 10
+	}
+	return utils.Round(value * 10 / best)
+}
+
+// getJSONLDNumber returns a numeric JSON-LD property that may be given as a number or a string.
+func getJSONLDNumber(data map[string]interface{}, key string) float64 {
+	switch value := data[key].(type) {
+	case float64:
+		return value
+	case string:
+		number, _ := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return number
+	}
+	return 0
+}
+
+// getMetaContent returns the content of the meta tag with the property or name, such as "og:title".
+func getMetaContent(doc *goquery.Document, property string) string {
+	selector := `meta[property="` + property + `"], meta[name="` + property + `"]`
+	return strings.TrimSpace(doc.Find(selector).First().AttrOr("content", ""))
+}
@@ -4,12 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/PuerkitoBio/goquery"
-	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
 	"github.com/k4sper1love/watchlist-bot/internal/services/client"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"github.com/k4sper1love/watchlist-bot/pkg/httpclient"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 )
@@ -19,29 +20,57 @@ const (
 	categorySeries = "series" // Category for series on Kinoafisha.
 )
 
-// GetFilmFromKinoafisha fetches film details from the Kinoafisha website using the provided URL.
-func GetFilmFromKinoafisha(session *models.Session, url string) (*apiModels.Film, error) {
-	return getMediaFromKinoafisha(session, url, categoryMovies, parseFilmFromKinoafisha)
+// kinoafishaSource parses films and series from Kinoafisha pages.
+type kinoafishaSource struct{}
+
+func init() {
+	Register(kinoafishaSource{})
+}
+
+// Name returns the name of the Kinoafisha source.
+func (kinoafishaSource) Name() string {
+	return "kinoafisha"
+}
+
+// MatchHost reports whether the host belongs to Kinoafisha.
+func (kinoafishaSource) MatchHost(host string) bool {
+	return matchDomain(host, "kinoafisha.info")
+}
+
+// ExtractID extracts the media ID from a movie or series page URL.
+func (s kinoafishaSource) ExtractID(u *url.URL) (string, error) {
+	_, id, err := s.parsePath(u)
+	return id, err
 }
 
-// GetSeriesFromKinoafisha fetches series details from the Kinoafisha website using the provided URL.
-func GetSeriesFromKinoafisha(session *models.Session, url string) (*apiModels.Film, error) {
-	return getMediaFromKinoafisha(session, url, categorySeries, parseSeriesFromKinoafisha)
+// Canonicalize returns the URL of the movie or series page.
+func (s kinoafishaSource) Canonicalize(u *url.URL) (*url.URL, error) {
+	category, id, err := s.parsePath(u)
+	if err != nil {
+		return nil, err
+	}
+	return url.Parse(fmt.Sprintf("https://www.kinoafisha.info/%s/%s/", category, id))
 }
 
-// getMediaFromKinoafisha is a helper function to fetch media (film or series) details from Kinoafisha.
-// It uses the provided URL, category, and parser function to extract and parse the data.
-func getMediaFromKinoafisha(session *models.Session, url, category string, parser func(*apiModels.Film, io.Reader) error) (*apiModels.Film, error) {
-	id, err := parseKinoafishaID(url)
+// Fetch fetches film or series details from the Kinoafisha page, depending on its category.
+func (s kinoafishaSource) Fetch(app models.App, session *models.Session, u *url.URL) (*ParsedFilm, error) {
+	category, _, err := s.parsePath(u)
 	if err != nil {
-		utils.LogParseFromURLError(session.TelegramID, "failed to parse ID", err, url)
+		utils.LogParseFromURLError(session.TelegramID, "failed to parse ID", err, u.String())
 		return nil, err
 	}
 
+	parser := parseFilmFromKinoafisha
+	if category == categorySeries {
+		parser = parseSeriesFromKinoafisha
+	}
+
 	resp, err := client.Do(
 		&client.CustomRequest{
+			Context:            app.Context(),
+			Service:            httpclient.External,
 			Method:             http.MethodGet, // HTTP GET method for fetching data.
-			URL:                fmt.Sprintf("https://www.kinoafisha.info/%s/%s", category, id),
+			URL:                u.String(),
 			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
 			TelegramID:         session.TelegramID,
 		},
@@ -51,7 +80,7 @@ func getMediaFromKinoafisha(session *models.Session, url, category string, parse
 	}
 	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
 
-	var film apiModels.Film
+	var film ParsedFilm
 	if err = parser(&film, resp.Body); err != nil {
 		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
 		return nil, err
@@ -60,26 +89,35 @@ func getMediaFromKinoafisha(session *models.Session, url, category string, parse
 	return &film, err
 }
 
-// parseFilmFromKinoafisha parses film details from the Kinoafisha HTML document into an `models.Film` object.
-func parseFilmFromKinoafisha(dest *apiModels.Film, data io.Reader) error {
+// parsePath extracts the category (movies or series) and the media ID from the Kinoafisha URL.
+func (kinoafishaSource) parsePath(u *url.URL) (string, string, error) {
+	segments := pathSegments(u)
+	if len(segments) < 2 || (segments[0] != categoryMovies && segments[0] != categorySeries) {
+		return "", "", fmt.Errorf("invalid Kinoafisha URL: %s", u)
+	}
+	return segments[0], segments[1], nil // The second part of the path is the media ID.
+}
+
+// parseFilmFromKinoafisha parses film details and metadata from the Kinoafisha HTML document into a `ParsedFilm` object.
+func parseFilmFromKinoafisha(dest *ParsedFilm, data io.Reader) error {
 	doc, err := goquery.NewDocumentFromReader(data)
 	if err != nil {
 		return err
 	}
 
 	// Extract film details from the HTML document.
-	dest.Title = strings.Split(getTextOrDefault(doc, ".newFilmInfo_title", "Unknown"), ",")[0] // Extract the title.
-	dest.Year = getKinoafishaYear(doc)                                                         // Extract the release year.
-	dest.Genre = getTextOrDefault(doc, ".newFilmInfo_genreItem", "")                           // Extract the genre.
-	dest.Description = getTextOrDefault(doc, ".more_content p", "")                            // Extract the description.
-	dest.Rating = parseKinoafishaRating(getTextOrDefault(doc, ".rating_imdb", "0"))            // Extract and parse the rating.
-	dest.ImageURL = getKinoafishaImageURL(doc)                                                 // Extract the image URL.
+	dest.Film.Title = strings.Split(getTextOrDefault(doc, ".newFilmInfo_title", "Unknown"), ",")[0] // Extract the title.
+	dest.Film.Year = getKinoafishaYear(doc)                                                         // Extract the release year.
+	dest.Film.Description = getTextOrDefault(doc, ".more_content p", "")                            // Extract the description.
+	dest.Film.Rating = parseKinoafishaRating(getTextOrDefault(doc, ".rating_imdb", "0"))            // Extract and parse the rating.
+	dest.Film.ImageURL = getKinoafishaImageURL(doc)                                                 // Extract the image URL.
+	dest.Metadata = getKinoafishaMetadata(doc)                                                      // Extract the genres and other details.
 
 	return nil
 }
 
-// parseSeriesFromKinoafisha parses series details from the Kinoafisha HTML document into an `models.Film` object.
-func parseSeriesFromKinoafisha(dest *apiModels.Film, data io.Reader) error {
+// parseSeriesFromKinoafisha parses series details and metadata from the Kinoafisha HTML document into a `ParsedFilm` object.
+func parseSeriesFromKinoafisha(dest *ParsedFilm, data io.Reader) error {
 	doc, err := goquery.NewDocumentFromReader(data)
 	if err != nil {
 		return err
@@ -88,16 +126,23 @@ func parseSeriesFromKinoafisha(dest *apiModels.Film, data io.Reader) error {
 	// Extract series title from breadcrumbs.
 	doc.Find(".newFilmInfo_breadcrumbs .breadcrumbs_item").Each(func(i int, s *goquery.Selection) {
 		if i == 2 { // The third breadcrumb item typically contains the series title.
-			dest.Title = strings.TrimSpace(s.Text())
+			dest.Film.Title = strings.TrimSpace(s.Text())
 		}
 	})
 
 	// Extract other series details.
-	dest.Year = getKinoafishaYear(doc)                                                              // Extract the release year.
-	dest.Genre = getTextOrDefault(doc, ".newFilmInfo_genreItem", "")                                // Extract the genre.
-	dest.Description = getTextOrDefault(doc, ".more_content p", "")                                 // Extract the description.
-	dest.Rating = parseKinoafishaRating(getTextOrDefault(doc, ".ratingBlockCard_externalVal", "0")) // Extract and parse the rating.
-	dest.ImageURL = getKinoafishaImageURL(doc)                                                      // Extract the image URL.
+	dest.Film.Year = getKinoafishaYear(doc)                                                              // Extract the release year.
+	dest.Film.Description = getTextOrDefault(doc, ".more_content p", "")                                 // Extract the description.
+	dest.Film.Rating = parseKinoafishaRating(getTextOrDefault(doc, ".ratingBlockCard_externalVal", "0")) // Extract and parse the rating.
+	dest.Film.ImageURL = getKinoafishaImageURL(doc)                                                      // Extract the image URL.
+	dest.Metadata = getKinoafishaMetadata(doc)                                                           // Extract the genres and other details.
+
+	// Extract the season structure; Kinoafisha only lists the numbers of seasons and episodes.
+	dest.Metadata.Seasons = make([]int, min(parseKinoafishaNumber(getKinoafishaInfo(doc, "Сезон")), maxSeasons))
+	dest.Metadata.Episodes = parseKinoafishaNumber(getKinoafishaInfo(doc, "Серий"))
+	if len(dest.Metadata.Seasons) == 1 {
+		dest.Metadata.Seasons[0] = dest.Metadata.Episodes // The episodes of a single season are known.
+	}
 
 	return nil
 }
@@ -125,6 +170,47 @@ func parseKinoafishaRating(ratingStr string) float64 {
 	return rating
 }
 
+// getKinoafishaMetadata extracts all genres and the details of the information list from the Kinoafisha HTML document.
+func getKinoafishaMetadata(doc *goquery.Document) FilmMetadata {
+	var metadata FilmMetadata
+	doc.Find(".newFilmInfo_genreItem").Each(func(i int, s *goquery.Selection) {
+		if genre := strings.TrimSpace(s.Text()); genre != "" {
+			metadata.Genres = append(metadata.Genres, genre)
+		}
+	})
+
+	metadata.Countries = getKinoafishaInfo(doc, "Страна")
+	metadata.Directors = getKinoafishaInfo(doc, "Режиссер")
+	metadata.Cast = getKinoafishaInfo(doc, "В ролях")
+	metadata.Runtime = parseRuntime(strings.Join(getKinoafishaInfo(doc, "Продолжительность"), ""))
+	return metadata
+}
+
+// getKinoafishaInfo extracts the comma-separated values of the item of the Kinoafisha information list with the name.
+func getKinoafishaInfo(doc *goquery.Document, name string) []string {
+	var values []string
+	doc.Find(".newFilmInfo_infoItem").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		if !strings.Contains(s.Find(".newFilmInfo_infoName").Text(), name) {
+			return true
+		}
+
+		for _, value := range strings.Split(s.Find(".newFilmInfo_infoData").Text(), ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		return false
+	})
+	return values
+}
+
+// parseKinoafishaNumber parses the first number in the values of an item of the Kinoafisha information list,
+// such as 3 in "3 сезона", returning 0 if there is none.
+func parseKinoafishaNumber(values []string) int {
+	number, _ := strconv.Atoi(numberRegex.FindString(strings.Join(values, " ")))
+	return number
+}
+
 // getKinoafishaYear extracts the release year from the Kinoafisha HTML document.
 func getKinoafishaYear(doc *goquery.Document) int {
 	var year int
@@ -136,12 +222,3 @@ func getKinoafishaYear(doc *goquery.Document) int {
 
 	return year
 }
-
-// parseKinoafishaID extracts the media ID from the Kinoafisha URL.
-func parseKinoafishaID(url string) (string, error) {
-	parts := strings.Split(strings.TrimPrefix(url, "https://www.kinoafisha.info/"), "/")
-	if len(parts) > 0 {
-		return parts[1], nil // The second part of the path is the media ID.
-	}
-	return "", fmt.Errorf("invalid Kinoafisha URL: %s", url)
-}
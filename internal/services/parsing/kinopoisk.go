@@ -8,26 +8,76 @@ import (
 	"github.com/k4sper1love/watchlist-bot/internal/models"
 	"github.com/k4sper1love/watchlist-bot/internal/services/client"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"github.com/k4sper1love/watchlist-bot/pkg/httpclient"
 	"github.com/k4sper1love/watchlist-bot/pkg/security"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 )
 
-// GetFilmFromKinopoisk fetches a single film from the Kinopoisk API using the provided URL.
+// kinopoiskName is the name of the Kinopoisk source.
+const kinopoiskName = "kinopoisk"
+
+// kinopoiskAPIURL is the base URL of the Kinopoisk API.
+const kinopoiskAPIURL = "https://api.kinopoisk.dev/v1.4"
+
+// kinopoiskSource parses films from Kinopoisk pages through the Kinopoisk API.
+type kinopoiskSource struct{}
+
+func init() {
+	Register(kinopoiskSource{})
+}
+
+// Name returns the name of the Kinopoisk source.
+func (kinopoiskSource) Name() string {
+	return kinopoiskName
+}
+
+// MatchHost reports whether the host belongs to Kinopoisk, including Kinopoisk HD.
+func (kinopoiskSource) MatchHost(host string) bool {
+	return matchDomain(host, "kinopoisk.ru")
+}
+
+// ExtractID extracts the Kinopoisk ID from a film page URL or the Kinopoisk HD ID from an "rt" query parameter.
+func (kinopoiskSource) ExtractID(u *url.URL) (string, error) {
+	_, id, err := utils.ExtractKinopoiskQuery(u.String())
+	return id, err
+}
+
+// Canonicalize returns the URL of the film or series page, or the Kinopoisk HD URL with only the "rt" parameter.
+func (kinopoiskSource) Canonicalize(u *url.URL) (*url.URL, error) {
+	queryKey, id, err := utils.ExtractKinopoiskQuery(u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if queryKey != "id" {
+		return url.Parse(fmt.Sprintf("https://hd.kinopoisk.ru/?rt=%s", url.QueryEscape(id)))
+	}
+
+	kind := "film"
+	if segments := pathSegments(u); segments[0] == "series" {
+		kind = "series"
+	}
+	return url.Parse(fmt.Sprintf("https://www.kinopoisk.ru/%s/%s/", kind, id))
+}
+
+// Fetch fetches a single film from the Kinopoisk API using the provided URL.
 // It extracts the query and ID from the URL, makes an HTTP request to the Kinopoisk API,
-// and parses the response into an `models.Film` object.
-func GetFilmFromKinopoisk(session *models.Session, url string) (*apiModels.Film, error) {
-	queryKey, id, err := utils.ExtractKinopoiskQuery(url)
+// and parses the response into a `ParsedFilm` object.
+func (kinopoiskSource) Fetch(app models.App, session *models.Session, u *url.URL) (*ParsedFilm, error) {
+	queryKey, id, err := utils.ExtractKinopoiskQuery(u.String())
 	if err != nil {
-		utils.LogParseFromURLError(session.TelegramID, "failed to extract query", err, url)
+		utils.LogParseFromURLError(session.TelegramID, "failed to extract query", err, u.String())
 		return nil, err
 	}
 
 	// Construct the API URL using the extracted query key and ID.
-	apiURL := fmt.Sprintf("https://api.kinopoisk.dev/v1.4/movie?%s=%s", queryKey, id)
+	apiURL := fmt.Sprintf("%s/movie?%s=%s", kinopoiskAPIURL, queryKey, id)
 
-	resp, err := getDataFromKinopoisk(session, apiURL)
+	resp, err := getDataFromKinopoisk(app, session, apiURL)
 	if err != nil {
 		return nil, err
 	}
@@ -43,22 +93,18 @@ func GetFilmFromKinopoisk(session *models.Session, url string) (*apiModels.Film,
 }
 
 // GetFilmsFromKinopoisk fetches a list of films from the Kinopoisk API based on the current session state.
-// It constructs a search query using the session's title, page, and page size, and parses the response
+// It constructs a search query using the session's title, page, page size and search filters, and parses the response
 // into a list of `models.Film` objects along with metadata.
-func GetFilmsFromKinopoisk(session *models.Session) ([]apiModels.Film, *filters.Metadata, error) {
-	state := session.FilmsState
-	query := url.QueryEscape(state.Title) // Escape the title to ensure it's URL-safe.
+func GetFilmsFromKinopoisk(app models.App, session *models.Session) ([]apiModels.Film, *filters.Metadata, error) {
+	apiURL := getKinopoiskSearchURL(session.FilmsState)
 
-	// Construct the API URL using the extracted query key and ID.
-	apiURL := fmt.Sprintf("https://api.kinopoisk.dev/v1.4/movie/search?page=%d&limit=%d&query=%s", state.CurrentPage, state.PageSize, query)
-
-	resp, err := getDataFromKinopoisk(session, apiURL)
+	resp, err := getDataFromKinopoisk(app, session, apiURL)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
 
-	films, metadata, err := parseFilmsFromKinopoisk(resp.Body)
+	films, metadata, err := parseFilmsFromKinopoisk(session, resp.Body)
 	if err != nil {
 		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
 		return nil, nil, err
@@ -67,10 +113,65 @@ func GetFilmsFromKinopoisk(session *models.Session) ([]apiModels.Film, *filters.
 	return films, metadata, nil
 }
 
-// getDataFromKinopoisk sends an HTTP GET request to the Kinopoisk API with the required API key.
-// The API key is decrypted from the session's KinopoiskAPIToken before being used.
-func getDataFromKinopoisk(session *models.Session, url string) (*http.Response, error) {
-	token, err := security.Decrypt(session.KinopoiskAPIToken)
+// getKinopoiskSearchURL returns the URL of the Kinopoisk search for the films state.
+// Without search filters, films are searched by title, ordered by relevance.
+// With them, the filtered movie endpoint is used, which matches the title by name and orders films by popularity.
+func getKinopoiskSearchURL(state *models.FilmsState) string {
+	query := url.Values{
+		"page":  {strconv.Itoa(state.CurrentPage)},
+		"limit": {strconv.Itoa(state.PageSize)},
+	}
+
+	searchFilters := state.SearchFilters
+	if !searchFilters.IsEnabled() {
+		query.Set("query", state.Title)
+		return kinopoiskAPIURL + "/movie/search?" + query.Encode()
+	}
+
+	query.Set("sortField", "votes.kp")
+	query.Set("sortType", "-1")
+	setQueryIfNotEmpty(query, "name", state.Title)
+	setQueryIfNotEmpty(query, "year", searchFilters.Year)
+	setQueryIfNotEmpty(query, "type", searchFilters.Type)
+	setQueryIfNotEmpty(query, "genres.name", searchFilters.Genre)
+	setQueryIfNotEmpty(query, "countries.name", searchFilters.Country)
+
+	if rating := searchFilters.Rating; rating != "" && !strings.Contains(rating, "-") {
+		query.Set("rating.kp", rating+"-10") // A single rating is the minimum one.
+	} else {
+		setQueryIfNotEmpty(query, "rating.kp", rating)
+	}
+
+	return kinopoiskAPIURL + "/movie?" + query.Encode()
+}
+
+// setQueryIfNotEmpty sets the query parameter if the value is not empty.
+func setQueryIfNotEmpty(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// CheckKinopoiskToken validates an encrypted Kinopoisk API token by requesting a single film ID,
+// which is the cheapest request available in the Kinopoisk API.
+func CheckKinopoiskToken(app models.App, session *models.Session, encryptedToken string) error {
+	resp, err := getDataWithToken(app, session, encryptedToken, kinopoiskAPIURL+"/movie?page=1&limit=1&selectFields=id")
+	if err != nil {
+		return err
+	}
+	utils.CloseBody(resp.Body)
+	return nil
+}
+
+// getDataFromKinopoisk sends an HTTP GET request to the Kinopoisk API with the session's KinopoiskAPIToken.
+func getDataFromKinopoisk(app models.App, session *models.Session, url string) (*http.Response, error) {
+	return getDataWithToken(app, session, session.KinopoiskAPIToken, url)
+}
+
+// getDataWithToken sends an HTTP GET request to the Kinopoisk API with the required API key.
+// The API key is decrypted from the given encrypted token before being used.
+func getDataWithToken(app models.App, session *models.Session, encryptedToken, url string) (*http.Response, error) {
+	token, err := security.Decrypt(encryptedToken)
 	if err != nil {
 		utils.LogDecryptError(session.TelegramID, err)
 		return nil, err
@@ -78,6 +179,8 @@ func getDataFromKinopoisk(session *models.Session, url string) (*http.Response,
 
 	return client.Do(
 		&client.CustomRequest{
+			Context:            app.Context(),
+			Service:            httpclient.Kinopoisk,
 			HeaderType:         client.HeaderExternalAPIKey, // Use the external API key header.
 			HeaderValue:        token,
 			Method:             http.MethodGet, // HTTP GET method for fetching data.
@@ -89,7 +192,7 @@ func getDataFromKinopoisk(session *models.Session, url string) (*http.Response,
 }
 
 // parseFilmFromKinopoisk parses a single film from the Kinopoisk API response.
-func parseFilmFromKinopoisk(data io.Reader) (*apiModels.Film, error) {
+func parseFilmFromKinopoisk(data io.Reader) (*ParsedFilm, error) {
 	var response map[string]interface{}
 	if err := json.NewDecoder(data).Decode(&response); err != nil {
 		return nil, err
@@ -104,7 +207,7 @@ func parseFilmFromKinopoisk(data io.Reader) (*apiModels.Film, error) {
 }
 
 // parseFilmsFromKinopoisk parses a list of films and metadata from the Kinopoisk API response.
-func parseFilmsFromKinopoisk(data io.Reader) ([]apiModels.Film, *filters.Metadata, error) {
+func parseFilmsFromKinopoisk(session *models.Session, data io.Reader) ([]apiModels.Film, *filters.Metadata, error) {
 	var response map[string]interface{}
 	if err := json.NewDecoder(data).Decode(&response); err != nil {
 		return nil, nil, err
@@ -122,7 +225,7 @@ func parseFilmsFromKinopoisk(data io.Reader) ([]apiModels.Film, *filters.Metadat
 			continue
 		}
 
-		film := parseFilmDataKinopoisk(filmData)
+		film := parseFilmDataKinopoisk(filmData).ToFilm(session)
 		film.URL = fmt.Sprintf("https://www.kinopoisk.ru/film/%d/", film.ID)
 
 		films = append(films, *film)
@@ -139,27 +242,86 @@ func parseFilmsFromKinopoisk(data io.Reader) ([]apiModels.Film, *filters.Metadat
 	return films, &metadata, nil
 }
 
-// parseFilmDataKinopoisk extracts film details from the Kinopoisk API response data.
-func parseFilmDataKinopoisk(data map[string]interface{}) *apiModels.Film {
-	return &apiModels.Film{
-		ID:          getIntFromMap(data, "id", 0),                      // Extract the film ID.
-		Title:       getStringFromMap(data, "name", "Unknown"),         // Extract the film title.
-		Year:        getIntFromMap(data, "year", 0),                    // Extract the release year.
-		Genre:       getFirstGenre(data, ""),                           // Extract the first genre.
-		Description: getStringFromMap(data, "description", ""),         // Extract the description.
-		Rating:      getFloatFromNestedMap(data, "rating", "kp", 0.0),  // Extract the Kinopoisk rating.
-		ImageURL:    getStringFromNestedMap(data, "poster", "url", ""), // Extract the poster image URL.
+// parseFilmDataKinopoisk extracts film details and metadata from the Kinopoisk API response data.
+// Search results have no persons, so directors and cast are only found in film details.
+func parseFilmDataKinopoisk(data map[string]interface{}) *ParsedFilm {
+	return &ParsedFilm{
+		Film: apiModels.Film{
+			ID:          getIntFromMap(data, "id", 0),                      // Extract the film ID.
+			Title:       getStringFromMap(data, "name", "Unknown"),         // Extract the film title.
+			Year:        getIntFromMap(data, "year", 0),                    // Extract the release year.
+			Description: getStringFromMap(data, "description", ""),         // Extract the description.
+			Rating:      getFloatFromNestedMap(data, "rating", "kp", 0.0),  // Extract the Kinopoisk rating.
+			ImageURL:    getStringFromNestedMap(data, "poster", "url", ""), // Extract the poster image URL.
+		},
+		Metadata: FilmMetadata{
+			OriginalTitle: getStringFromMap(data, "alternativeName", ""),
+			Genres:        getNamesFromList(data, "genres"),
+			Runtime:       getIntFromMap(data, "movieLength", getIntFromMap(data, "seriesLength", 0)),
+			Seasons:       getKinopoiskSeasons(data),
+			Countries:     getNamesFromList(data, "countries"),
+			Directors:     getKinopoiskPersons(data, "director"),
+			Cast:          getKinopoiskPersons(data, "actor"),
+			AgeRating:     getKinopoiskAgeRating(data),
+		},
 	}
 }
 
-// getFirstGenre extracts the first genre from the "genres" field in the Kinopoisk API response.
-func getFirstGenre(data map[string]interface{}, defaultValue string) string {
-	if genres, ok := data["genres"].([]interface{}); ok && len(genres) > 0 {
-		if firstGenre, ok := genres[0].(map[string]interface{}); ok {
-			if genreName, ok := firstGenre["name"].(string); ok {
-				return genreName // Return the name of the first genre.
+// getNamesFromList extracts the names of the objects in a list field, such as "genres" in the Kinopoisk API response.
+func getNamesFromList(data map[string]interface{}, key string) []string {
+	items, _ := data[key].([]interface{})
+
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		if object, ok := item.(map[string]interface{}); ok {
+			if name := getStringFromMap(object, "name", ""); name != "" {
+				names = append(names, name)
 			}
 		}
 	}
-	return defaultValue // Return the default value if no genre is found.
+	return names
+}
+
+// getKinopoiskSeasons extracts the number of episodes in each season of a series, ordered by the season numbers.
+// Specials, numbered 0, are left out.
+func getKinopoiskSeasons(data map[string]interface{}) []int {
+	items, _ := data["seasonsInfo"].([]interface{})
+
+	var seasons []int
+	for _, item := range items {
+		season, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if number := getIntFromMap(season, "number", 0); number > 0 && number <= maxSeasons {
+			seasons = append(seasons, make([]int, max(0, number-len(seasons)))...)
+			seasons[number-1] = getIntFromMap(season, "episodesCount", 0)
+		}
+	}
+	return seasons
+}
+
+// getKinopoiskPersons extracts the names of the persons with the profession, such as "director" or "actor".
+func getKinopoiskPersons(data map[string]interface{}, profession string) []string {
+	persons, _ := data["persons"].([]interface{})
+
+	var names []string
+	for _, item := range persons {
+		person, ok := item.(map[string]interface{})
+		if !ok || getStringFromMap(person, "enProfession", "") != profession {
+			continue
+		}
+		if name := firstNonEmpty(getStringFromMap(person, "name", ""), getStringFromMap(person, "enName", "")); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// getKinopoiskAgeRating formats the Russian age rating, such as "18+", or the MPAA rating if there is none.
+func getKinopoiskAgeRating(data map[string]interface{}) string {
+	if age, ok := data["ageRating"].(float64); ok {
+		return fmt.Sprintf("%d+", int(age))
+	}
+	return strings.ToUpper(getStringFromMap(data, "ratingMpaa", ""))
 }
@@ -0,0 +1,44 @@
+package parsing
+
+import (
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"net/url"
+	"testing"
+)
+
+func TestKinopoiskSearchURL(t *testing.T) {
+	state := &models.FilmsState{Title: "Dune", CurrentPage: 2, PageSize: 4}
+
+	if got, want := getKinopoiskSearchURL(state), kinopoiskAPIURL+"/movie/search?limit=4&page=2&query=Dune"; got != want {
+		t.Errorf("URL without filters = %q, want the title search %q", got, want)
+	}
+
+	state.SearchFilters = models.SearchFilters{Year: "2020-2025", Type: "movie", Genre: "фантастика", Country: "США", Rating: "7"}
+	u, err := url.Parse(getKinopoiskSearchURL(state))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.Path != "/v1.4/movie" {
+		t.Errorf("path = %q, want the filtered movie endpoint", u.Path)
+	}
+
+	want := map[string]string{
+		"name":           "Dune",
+		"year":           "2020-2025",
+		"type":           "movie",
+		"genres.name":    "фантастика",
+		"countries.name": "США",
+		"rating.kp":      "7-10",
+		"page":           "2",
+	}
+	for key, value := range want {
+		if got := u.Query().Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+
+	state.SearchFilters = models.SearchFilters{Rating: "6-8"}
+	if u, _ := url.Parse(getKinopoiskSearchURL(state)); u.Query().Get("rating.kp") != "6-8" || u.Query().Has("year") {
+		t.Errorf("URL = %q, want only the rating range", u)
+	}
+}
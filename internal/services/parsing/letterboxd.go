@@ -0,0 +1,178 @@
+package parsing
+
+import (
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/client"
+	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"github.com/k4sper1love/watchlist-bot/pkg/httpclient"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	letterboxdHost      = "letterboxd.com" // Host of the Letterboxd website.
+	letterboxdShortHost = "boxd.it"        // Host of short Letterboxd links.
+)
+
+// letterboxdSource parses films from Letterboxd pages, including short boxd.it links.
+type letterboxdSource struct{}
+
+func init() {
+	Register(letterboxdSource{})
+}
+
+// Name returns the name of the Letterboxd source.
+func (letterboxdSource) Name() string {
+	return "letterboxd"
+}
+
+// MatchHost reports whether the host belongs to Letterboxd or its link shortener.
+func (letterboxdSource) MatchHost(host string) bool {
+	return matchDomain(host, letterboxdHost, letterboxdShortHost)
+}
+
+// ExtractID extracts the film slug from a film page URL, such as "the-matrix" in "/film/the-matrix/",
+// or the code of a short link.
+func (letterboxdSource) ExtractID(u *url.URL) (string, error) {
+	segments := pathSegments(u)
+
+	if matchDomain(strings.ToLower(u.Hostname()), letterboxdShortHost) {
+		if len(segments) == 1 {
+			return segments[0], nil // The only segment of a short link is its code.
+		}
+		return "", fmt.Errorf("invalid Letterboxd short link: %s", u)
+	}
+
+	// Film pages may be nested in user pages, such as "/user/film/the-matrix/".
+	for i, segment := range segments {
+		if segment == "film" && i+1 < len(segments) {
+			return segments[i+1], nil
+		}
+	}
+	return "", fmt.Errorf("invalid Letterboxd URL: %s", u)
+}
+
+// Canonicalize returns the URL of the film page, or the short link itself, which is resolved when fetching.
+func (s letterboxdSource) Canonicalize(u *url.URL) (*url.URL, error) {
+	id, err := s.ExtractID(u)
+	if err != nil {
+		return nil, err
+	}
+
+	if matchDomain(strings.ToLower(u.Hostname()), letterboxdShortHost) {
+		return url.Parse(fmt.Sprintf("https://%s/%s", letterboxdShortHost, id))
+	}
+	return url.Parse(fmt.Sprintf("https://%s/film/%s/", letterboxdHost, id))
+}
+
+// Fetch fetches film details from the Letterboxd film page.
+// Short links are resolved to the film page first; the URL of the returned film is the film page.
+func (s letterboxdSource) Fetch(app models.App, session *models.Session, u *url.URL) (*ParsedFilm, error) {
+	page := u
+	if u.Host == letterboxdShortHost {
+		resolved, err := s.resolveShortLink(app, session, u)
+		if err != nil {
+			return nil, err
+		}
+		page = resolved
+	}
+
+	resp, err := client.Do(
+		&client.CustomRequest{
+			Context:            app.Context(),
+			Service:            httpclient.External,
+			Method:             http.MethodGet, // HTTP GET method for fetching data.
+			URL:                page.String(),
+			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
+			TelegramID:         session.TelegramID,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
+
+	film, err := parseFilmFromLetterboxd(resp.Body)
+	if err != nil {
+		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
+		return nil, err
+	}
+
+	film.Film.URL = page.String()
+	return film, nil
+}
+
+// resolveShortLink follows the redirect of a boxd.it link and returns the canonical URL of the film page it leads to.
+func (s letterboxdSource) resolveShortLink(app models.App, session *models.Session, u *url.URL) (*url.URL, error) {
+	resp, err := client.Do(
+		&client.CustomRequest{
+			Context:            app.Context(),
+			Service:            httpclient.External,
+			Method:             http.MethodGet, // Redirects are followed by the HTTP client.
+			URL:                u.String(),
+			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response from the target page.
+			TelegramID:         session.TelegramID,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	utils.CloseBody(resp.Body)
+
+	resolved, err := s.Canonicalize(resp.Request.URL)
+	if err != nil || resolved.Host == letterboxdShortHost {
+		utils.LogParseFromURLError(session.TelegramID, "failed to resolve short link", fmt.Errorf("not a film page"), resp.Request.URL.String())
+		return nil, fmt.Errorf("short link does not lead to a film page: %s", u)
+	}
+	return resolved, nil
+}
+
+// parseFilmFromLetterboxd parses film details and metadata from the JSON-LD of the Letterboxd film page
+// into a `ParsedFilm` object. The description is taken from the page's meta tags.
+func parseFilmFromLetterboxd(data io.Reader) (*ParsedFilm, error) {
+	doc, err := goquery.NewDocumentFromReader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	movie := findJSONLD(doc, "Movie")
+	if movie == nil {
+		return nil, fmt.Errorf("film data not found on the page")
+	}
+
+	return &ParsedFilm{
+		Film: apiModels.Film{
+			Title:       getJSONLDString(movie, "name", "Unknown"),
+			Year:        getLetterboxdYear(doc, movie),
+			Description: getMetaContent(doc, "og:description"),
+			Rating:      getJSONLDRating(movie),
+			ImageURL:    getJSONLDImage(movie),
+		},
+		Metadata: getJSONLDMetadata(movie),
+	}, nil
+}
+
+// getLetterboxdYear extracts the release year from the JSON-LD release event
+// or, if it is missing, from the page title, such as "The Matrix (1999)".
+func getLetterboxdYear(doc *goquery.Document, movie map[string]interface{}) int {
+	if events, ok := movie["releasedEvent"].([]interface{}); ok && len(events) > 0 {
+		if event, ok := events[0].(map[string]interface{}); ok {
+			if year := parseYearFromDate(getStringFromMap(event, "startDate", "")); year != 0 {
+				return year
+			}
+		}
+	}
+
+	title := getMetaContent(doc, "og:title")
+	if open, end := strings.LastIndex(title, "("), strings.LastIndex(title, ")"); open >= 0 && end > open {
+		year, _ := strconv.Atoi(title[open+1 : end])
+		return year
+	}
+	return 0
+}
@@ -0,0 +1,110 @@
+package parsing
+
+import (
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"strings"
+	"testing"
+)
+
+// parseLetterboxdFixture parses a film from a Letterboxd fixture as it is stored for an English-speaking user.
+func parseLetterboxdFixture(t *testing.T, name string) *apiModels.Film {
+	t.Helper()
+
+	parsed, err := parseFilmFromLetterboxd(openFixture(t, name))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return parsed.ToFilm(&models.Session{Lang: "en"})
+}
+
+func TestParseFilmFromLetterboxd(t *testing.T) {
+	film := parseLetterboxdFixture(t, "letterboxd_film.html")
+
+	if film.Title != "The Matrix" {
+		t.Errorf("title = %q, want %q", film.Title, "The Matrix")
+	}
+	if film.Year != 1999 {
+		t.Errorf("year = %d, want 1999", film.Year)
+	}
+	if film.Genre != "Action, Science Fiction" {
+		t.Errorf("genre = %q, want %q", film.Genre, "Action, Science Fiction")
+	}
+	if film.Rating != 8.54 {
+		t.Errorf("rating = %v, want 8.54", film.Rating)
+	}
+	if !strings.HasSuffix(film.ImageURL, "51518-the-matrix-0-230-0-345-crop.jpg") {
+		t.Errorf("image URL = %q, want the poster", film.ImageURL)
+	}
+	if !strings.HasPrefix(film.Description, "Set in the 22nd century") {
+		t.Errorf("description = %q, want the page description", film.Description)
+	}
+	if !strings.Contains(film.Description, "\n🎬 Director: Lilly Wachowski, Lana Wachowski\n") {
+		t.Errorf("description = %q, want the directors", film.Description)
+	}
+}
+
+func TestParseFilmFromLetterboxdWithoutOptionalData(t *testing.T) {
+	film := parseLetterboxdFixture(t, "letterboxd_unrated.html")
+
+	if film.Year != 2024 {
+		t.Errorf("year = %d, want 2024 from the page title", film.Year)
+	}
+	if film.Genre != "Documentary" {
+		t.Errorf("genre = %q, want %q", film.Genre, "Documentary")
+	}
+	if film.Rating != 0 || film.ImageURL != "" {
+		t.Errorf("rating = %v, image URL = %q, want none", film.Rating, film.ImageURL)
+	}
+	if film.Description != "🎬 Director: Jane Doe" {
+		t.Errorf("description = %q, want only the director", film.Description)
+	}
+}
+
+func TestParseFilmFromLetterboxdWithoutFilmData(t *testing.T) {
+	if _, err := parseFilmFromLetterboxd(strings.NewReader("<html></html>")); err == nil {
+		t.Fatal("expected an error for a page without film data")
+	}
+}
+
+func TestLetterboxdCanonicalize(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://letterboxd.com/film/the-matrix/", "https://letterboxd.com/film/the-matrix/"},
+		{"letterboxd.com/film/the-matrix/reviews/by/activity/", "https://letterboxd.com/film/the-matrix/"},
+		{"https://letterboxd.com/someone/film/the-matrix/1/?utm_source=share", "https://letterboxd.com/film/the-matrix/"},
+		{"https://boxd.it/29qo", "https://boxd.it/29qo"},
+	}
+
+	for _, tt := range tests {
+		source, u, err := FindSource(tt.url)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.url, err)
+		}
+		if source.Name() != "letterboxd" {
+			t.Fatalf("%s: source = %q, want letterboxd", tt.url, source.Name())
+		}
+
+		canonical, err := source.Canonicalize(u)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.url, err)
+		}
+		if canonical.String() != tt.want {
+			t.Errorf("%s: canonical URL = %q, want %q", tt.url, canonical, tt.want)
+		}
+	}
+}
+
+func TestLetterboxdRejectsNonFilmPages(t *testing.T) {
+	for _, rawURL := range []string{"https://letterboxd.com/someone/", "https://boxd.it/"} {
+		source, u, err := FindSource(rawURL)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", rawURL, err)
+		}
+		if _, err = source.Canonicalize(u); err == nil {
+			t.Errorf("%s: expected an error", rawURL)
+		}
+	}
+}
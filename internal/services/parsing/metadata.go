@@ -0,0 +1,264 @@
+package parsing
+
+import (
+	"fmt"
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/pkg/translator"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxTitleLength       = 100  // Maximum length of a film title accepted by the Watchlist API.
+	maxGenreLength       = 100  // Maximum length of a film genre accepted by the Watchlist API.
+	maxDescriptionLength = 1000 // Maximum length of a film description accepted by the Watchlist API.
+	maxCastNames         = 5    // Maximum number of actors kept in the metadata.
+)
+
+// metadataFields lists the fields of the metadata block in their order, with the markers their lines start with
+// and the translation keys of their labels.
+var metadataFields = []struct {
+	marker string
+	key    string
+}{
+	{"🏷", "originalTitle"},
+	{"🔤", "alternativeTitles"},
+	{"⏱", "runtime"},
+	{"📺", "episodes"},
+	{"🗂", "seasons"},
+	{"▶️", "watchedEpisode"},
+	{"🌍", "countries"},
+	{"🎬", "director"},
+	{"👥", "cast"},
+	{"🔞", "ageRating"},
+}
+
+// ParsedFilm is a film parsed from an external service together with the details the film model has no fields for.
+type ParsedFilm struct {
+	Film     apiModels.Film // Film details stored in the film model.
+	Metadata FilmMetadata   // Other film details.
+}
+
+// FilmMetadata contains the film details that the film model has no fields for.
+// All lists are ordered as on the service and may be empty.
+type FilmMetadata struct {
+	OriginalTitle     string   // Title in the original language, if it differs from the title.
+	AlternativeTitles []string // Other titles, such as the English and Japanese titles of an anime.
+	Genres            []string // All genres; the film model only has one genre field.
+	Runtime           int      // Runtime in minutes, or the runtime of an episode of a series.
+	Episodes          int      // Number of episodes of a series.
+	Seasons           []int    // Number of episodes in each season of a series, or 0 if unknown.
+	Countries         []string // Countries of production.
+	Directors         []string // Directors.
+	Cast              []string // Leading actors.
+	AgeRating         string   // Age rating, such as "18+" or "PG-13".
+}
+
+// MetadataField is a line of the metadata block in a film description.
+type MetadataField struct {
+	Marker string // Emoji the line starts with, identifying the field.
+	Key    string // Translation key of the field label.
+	Value  string // Value of the field.
+}
+
+// ToFilm returns the film with all genres that fit in the genre field
+// and the metadata appended to the description as a block of labeled lines in the session language.
+// The title and description are shortened to the limits of the Watchlist API; the metadata is kept.
+func (p *ParsedFilm) ToFilm(session *models.Session) *apiModels.Film {
+	film := p.Film
+
+	film.Title = truncateText(strings.TrimSpace(film.Title), maxTitleLength)
+	if genres := joinGenres(p.Metadata.Genres); genres != "" {
+		film.Genre = genres
+	}
+	film.Description = buildDescription(film.Description, FormatMetadata(session.Lang, p.Metadata.Fields(session.Lang, film.Title)))
+
+	return &film
+}
+
+// Fields returns the non-empty fields of the metadata in the block order.
+// Titles equal to the film title are left out.
+func (m *FilmMetadata) Fields(lang, title string) []MetadataField {
+	values := map[string]string{}
+
+	if original := strings.TrimSpace(m.OriginalTitle); original != "" && !strings.EqualFold(original, title) {
+		values["originalTitle"] = original
+	}
+
+	// The original title leads the unique titles if it is shown, so it is not repeated among the alternative ones.
+	alternatives := uniqueTitles(title, append([]string{m.OriginalTitle}, m.AlternativeTitles...)...)
+	if values["originalTitle"] != "" {
+		alternatives = alternatives[1:]
+	}
+	values["alternativeTitles"] = strings.Join(alternatives, ", ")
+
+	if m.Runtime > 0 {
+		values["runtime"] = fmt.Sprintf("%d %s", m.Runtime, translator.Translate(lang, "minutesShort", nil, nil))
+	}
+	series := Series{Seasons: m.Seasons, Episodes: m.Episodes}
+	if total := series.Total(); total > 0 {
+		values["episodes"] = strconv.Itoa(total)
+	}
+	values["seasons"] = formatSeasons(m.Seasons)
+	values["countries"] = joinNonEmpty(m.Countries)
+	values["director"] = joinNonEmpty(m.Directors)
+	values["cast"] = joinNonEmpty(m.Cast[:min(len(m.Cast), maxCastNames)])
+	values["ageRating"] = strings.TrimSpace(m.AgeRating)
+
+	var fields []MetadataField
+	for _, field := range metadataFields {
+		if value := values[field.key]; value != "" {
+			fields = append(fields, MetadataField{Marker: field.marker, Key: field.key, Value: value})
+		}
+	}
+	return fields
+}
+
+// FormatMetadata formats the metadata fields as lines with labels in the language, such as "🎬 Director: Lana Wachowski".
+func FormatMetadata(lang string, fields []MetadataField) string {
+	lines := make([]string, len(fields))
+	for i, field := range fields {
+		lines[i] = fmt.Sprintf("%s %s: %s", field.Marker, translator.Translate(lang, field.Key, nil, nil), field.Value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SplitDescription splits a film description into the text and the fields of the metadata block at its end.
+// Lines are recognized by their markers, so the labels may be in any language.
+func SplitDescription(description string) (string, []MetadataField) {
+	lines := strings.Split(strings.TrimSpace(description), "\n")
+
+	var fields []MetadataField
+	end := len(lines)
+	for ; end > 0; end-- {
+		field, ok := parseMetadataLine(lines[end-1])
+		if !ok {
+			break
+		}
+		fields = append([]MetadataField{field}, fields...)
+	}
+
+	return strings.TrimSpace(strings.Join(lines[:end], "\n")), fields
+}
+
+// parseMetadataLine parses a line of the metadata block, such as "🎬 Director: Lana Wachowski".
+func parseMetadataLine(line string) (MetadataField, bool) {
+	for _, field := range metadataFields {
+		rest, ok := strings.CutPrefix(strings.TrimSpace(line), field.marker+" ")
+		if !ok {
+			continue
+		}
+		if _, value, ok := strings.Cut(rest, ": "); ok && strings.TrimSpace(value) != "" {
+			return MetadataField{Marker: field.marker, Key: field.key, Value: strings.TrimSpace(value)}, true
+		}
+	}
+	return MetadataField{}, false
+}
+
+// buildDescription joins the description text and the metadata block.
+// The text is shortened so that the whole description fits the Watchlist API limit and the metadata is kept.
+func buildDescription(text, metadata string) string {
+	text = strings.TrimSpace(text)
+	metadata = truncateText(metadata, maxDescriptionLength)
+	if metadata == "" {
+		return truncateText(text, maxDescriptionLength)
+	}
+	if text == "" {
+		return metadata
+	}
+
+	const separator = "\n\n"
+	available := maxDescriptionLength - len([]rune(metadata)) - len([]rune(separator))
+	if available <= 0 {
+		return metadata
+	}
+	return truncateText(text, available) + separator + metadata
+}
+
+// uniqueTitles returns the non-empty titles that differ from the main title, without duplicates.
+func uniqueTitles(title string, titles ...string) []string {
+	seen := map[string]bool{strings.ToLower(title): true}
+
+	var unique []string
+	for _, t := range titles {
+		t = strings.TrimSpace(t)
+		if key := strings.ToLower(t); t != "" && !seen[key] {
+			seen[key] = true
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
+
+// joinNonEmpty joins the non-empty values with commas.
+func joinNonEmpty(values []string) string {
+	var nonEmpty []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			nonEmpty = append(nonEmpty, value)
+		}
+	}
+	return strings.Join(nonEmpty, ", ")
+}
+
+// joinGenres joins the genres with commas, leaving out those that do not fit the Watchlist API limit.
+func joinGenres(genres []string) string {
+	var joined string
+	for _, genre := range genres {
+		if genre = strings.TrimSpace(genre); genre == "" {
+			continue
+		}
+
+		next := genre
+		if joined != "" {
+			next = joined + ", " + genre
+		}
+		if len([]rune(next)) > maxGenreLength {
+			break
+		}
+		joined = next
+	}
+	return joined
+}
+
+// parseRuntime parses a runtime in minutes from an ISO 8601 duration, such as "PT2H16M", or a text, such as "136 min",
+// "2 hr 16 min" or "24 min per ep". It returns 0 if the runtime cannot be parsed.
+func parseRuntime(value string) int {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(strings.ToUpper(value), "PT") {
+		duration, err := time.ParseDuration(strings.ToLower(value[2:]))
+		if err != nil {
+			return 0
+		}
+		return int(duration.Minutes())
+	}
+
+	// Numbers are minutes unless followed by hours, such as "hr" or "ч".
+	var minutes int
+	words := strings.Fields(value)
+	for i, word := range words {
+		number, err := strconv.Atoi(word)
+		if err != nil {
+			continue
+		}
+		if i+1 < len(words) && strings.ContainsRune("hHчЧ", []rune(words[i+1])[0]) {
+			number *= 60
+		}
+		minutes += number
+	}
+	return minutes
+}
+
+// truncateText shortens the text to the number of characters, ending it with an ellipsis if it is cut.
+func truncateText(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	if limit <= 1 {
+		return string(runes[:limit])
+	}
+	return strings.TrimSpace(string(runes[:limit-1])) + "…"
+}
@@ -0,0 +1,61 @@
+package parsing
+
+import (
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"strings"
+	"testing"
+)
+
+func TestSplitDescriptionReadsMetadataBack(t *testing.T) {
+	parsed := &ParsedFilm{
+		Film: apiModels.Film{Title: "Der Untergang", Description: "Traudl Junge recalls\nthe last days."},
+		Metadata: FilmMetadata{
+			OriginalTitle: "Der Untergang",
+			Genres:        []string{"Biography", "Drama", "War"},
+			Runtime:       156,
+			Countries:     []string{"Germany", "Austria"},
+			Directors:     []string{"Oliver Hirschbiegel"},
+			Cast:          []string{"Bruno Ganz", "Alexandra Maria Lara", "Ulrich Matthes", "Juliane Köhler", "Thomas Kretschmann", "Corinna Harfouch"},
+		},
+	}
+	film := parsed.ToFilm(&models.Session{Lang: "ru"})
+
+	if film.Genre != "Biography, Drama, War" {
+		t.Errorf("genre = %q, want all genres", film.Genre)
+	}
+
+	text, fields := SplitDescription(film.Description)
+	if text != "Traudl Junge recalls\nthe last days." {
+		t.Errorf("text = %q, want the description without the metadata", text)
+	}
+
+	var keys []string
+	for _, field := range fields {
+		keys = append(keys, field.Key)
+	}
+	if got := strings.Join(keys, ","); got != "runtime,countries,director,cast" {
+		t.Fatalf("fields = %s, want runtime, countries, director and cast without the original title equal to the title", got)
+	}
+	if fields[0].Value != "156 мин" || fields[3].Value != "Bruno Ganz, Alexandra Maria Lara, Ulrich Matthes, Juliane Köhler, Thomas Kretschmann" {
+		t.Errorf("fields = %+v, want the runtime in the session language and the leading actors", fields)
+	}
+}
+
+func TestParseRuntime(t *testing.T) {
+	tests := map[string]int{
+		"PT2H16M":       136,
+		"136 min":       136,
+		"136 мин.":      136,
+		"2 hr 4 min":    124,
+		"2 ч 16 мин":    136,
+		"24 min per ep": 24,
+		"N/A":           0,
+	}
+
+	for value, want := range tests {
+		if got := parseRuntime(value); got != want {
+			t.Errorf("parseRuntime(%q) = %d, want %d", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package parsing
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/k4sper1love/watchlist-api/pkg/filters"
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/client"
+	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"github.com/k4sper1love/watchlist-bot/pkg/httpclient"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	myAnimeListSiteURL = "https://myanimelist.net"  // Base URL of the MyAnimeList website.
+	jikanAPIURL        = "https://api.jikan.moe/v4" // Base URL of Jikan, the public MyAnimeList API.
+	jikanMaxLimit      = 25                         // Maximum number of results on a Jikan search page.
+)
+
+// jikanAnime represents an anime in Jikan search results and details.
+type jikanAnime struct {
+	ID            int          `json:"mal_id"`         // MyAnimeList ID.
+	Title         string       `json:"title"`          // Romanized title.
+	TitleEnglish  string       `json:"title_english"`  // English title.
+	TitleJapanese string       `json:"title_japanese"` // Japanese title.
+	TitleSynonyms []string     `json:"title_synonyms"` // Other titles.
+	Episodes      int          `json:"episodes"`       // Number of episodes, or 0 if unknown.
+	Duration      string       `json:"duration"`       // Runtime of an episode, such as "24 min per ep".
+	Score         float64      `json:"score"`          // Average user rating out of 10.
+	Year          int          `json:"year"`           // Year of the premiere season, or 0 if unknown.
+	Aired         jikanAired   `json:"aired"`          // Air dates.
+	Synopsis      string       `json:"synopsis"`       // English description.
+	Images        jikanImages  `json:"images"`         // Poster URLs.
+	Genres        []jikanGenre `json:"genres"`         // Genres.
+}
+
+// jikanAired represents the air dates of a Jikan anime.
+type jikanAired struct {
+	From string `json:"from"` // First air date (ISO 8601).
+}
+
+// jikanImages represents the poster URLs of a Jikan anime.
+type jikanImages struct {
+	JPG struct {
+		ImageURL      string `json:"image_url"`       // URL of the poster.
+		LargeImageURL string `json:"large_image_url"` // URL of the large poster.
+	} `json:"jpg"`
+}
+
+// jikanGenre represents a Jikan genre.
+type jikanGenre struct {
+	Name string `json:"name"` // English genre name.
+}
+
+// jikanSearchResponse represents a page of Jikan search results.
+type jikanSearchResponse struct {
+	Data       []jikanAnime `json:"data"` // Anime on the page.
+	Pagination struct {
+		Items struct {
+			Total int `json:"total"` // Number of results.
+		} `json:"items"`
+	} `json:"pagination"`
+}
+
+// myAnimeListSource parses anime from MyAnimeList pages through the Jikan API.
+type myAnimeListSource struct{}
+
+func init() {
+	Register(myAnimeListSource{})
+}
+
+// Name returns the name of the MyAnimeList source.
+func (myAnimeListSource) Name() string {
+	return "myanimelist"
+}
+
+// MatchHost reports whether the host belongs to MyAnimeList.
+func (myAnimeListSource) MatchHost(host string) bool {
+	return matchDomain(host, "myanimelist.net")
+}
+
+// ExtractID extracts the MyAnimeList ID from an anime page URL, such as "1" in "/anime/1/Cowboy_Bebop".
+func (myAnimeListSource) ExtractID(u *url.URL) (string, error) {
+	segments := pathSegments(u)
+	if len(segments) < 2 || segments[0] != "anime" {
+		return "", fmt.Errorf("invalid MyAnimeList URL: %s", u)
+	}
+	if _, err := strconv.Atoi(segments[1]); err != nil {
+		return "", fmt.Errorf("invalid MyAnimeList URL: %s", u)
+	}
+	return segments[1], nil
+}
+
+// Canonicalize returns the URL of the anime page without the title slug.
+func (s myAnimeListSource) Canonicalize(u *url.URL) (*url.URL, error) {
+	id, err := s.ExtractID(u)
+	if err != nil {
+		return nil, err
+	}
+	return url.Parse(fmt.Sprintf("%s/anime/%s", myAnimeListSiteURL, id))
+}
+
+// Fetch fetches anime details from the Jikan API.
+func (s myAnimeListSource) Fetch(app models.App, session *models.Session, u *url.URL) (*ParsedFilm, error) {
+	id, err := s.ExtractID(u)
+	if err != nil {
+		utils.LogParseFromURLError(session.TelegramID, "failed to parse ID", err, u.String())
+		return nil, err
+	}
+
+	var film *ParsedFilm
+	err = getDataFromJikan(app, session, "/anime/"+id, nil, func(data io.Reader) (err error) {
+		film, err = parseAnimeFromJikan(session, data)
+		return err
+	})
+	return film, err
+}
+
+// GetAnimeFromMyAnimeList searches anime on MyAnimeList by the session's title, page and page size.
+func GetAnimeFromMyAnimeList(app models.App, session *models.Session) ([]apiModels.Film, *filters.Metadata, error) {
+	state := session.FilmsState
+	pageSize := min(max(state.PageSize, 1), jikanMaxLimit)
+
+	query := url.Values{
+		"q":     {state.Title},
+		"page":  {strconv.Itoa(state.CurrentPage)},
+		"limit": {strconv.Itoa(pageSize)},
+	}
+
+	var films []apiModels.Film
+	var totalRecords int
+	err := getDataFromJikan(app, session, "/anime", query, func(data io.Reader) (err error) {
+		films, totalRecords, err = parseAnimeListFromJikan(session, data)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata := filters.CalculateMetadata(totalRecords, state.CurrentPage, pageSize)
+	return films, &metadata, nil
+}
+
+// getDataFromJikan sends an HTTP GET request to the Jikan API and passes the response body to parse.
+func getDataFromJikan(app models.App, session *models.Session, path string, query url.Values, parse func(io.Reader) error) error {
+	apiURL := jikanAPIURL + path
+	if len(query) > 0 {
+		apiURL += "?" + query.Encode()
+	}
+
+	resp, err := client.Do(
+		&client.CustomRequest{
+			Context:            app.Context(),
+			Service:            httpclient.External,
+			Method:             http.MethodGet, // HTTP GET method for fetching data.
+			URL:                apiURL,
+			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
+			TelegramID:         session.TelegramID,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
+
+	if err = parse(resp.Body); err != nil {
+		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
+		return err
+	}
+	return nil
+}
+
+// parseAnimeFromJikan parses anime details from the Jikan API response into a `ParsedFilm` object.
+func parseAnimeFromJikan(session *models.Session, data io.Reader) (*ParsedFilm, error) {
+	var response struct {
+		Data jikanAnime `json:"data"`
+	}
+	if err := json.NewDecoder(data).Decode(&response); err != nil {
+		return nil, err
+	}
+	if response.Data.ID == 0 {
+		return nil, fmt.Errorf("anime not found in the response")
+	}
+	return parseFilmFromJikan(session, &response.Data), nil
+}
+
+// parseAnimeListFromJikan parses Jikan search results into `models.Film` objects and returns them with the number of results.
+func parseAnimeListFromJikan(session *models.Session, data io.Reader) ([]apiModels.Film, int, error) {
+	var response jikanSearchResponse
+	if err := json.NewDecoder(data).Decode(&response); err != nil {
+		return nil, 0, err
+	}
+
+	films := make([]apiModels.Film, 0, len(response.Data))
+	for i := range response.Data {
+		films = append(films, *parseFilmFromJikan(session, &response.Data[i]).ToFilm(session))
+	}
+	return films, response.Pagination.Items.Total, nil
+}
+
+// parseFilmFromJikan converts a Jikan anime into a `ParsedFilm` object.
+// The English title is preferred for English users.
+func parseFilmFromJikan(session *models.Session, anime *jikanAnime) *ParsedFilm {
+	title := anime.Title
+	if session.Lang == "en" && anime.TitleEnglish != "" {
+		title = anime.TitleEnglish
+	}
+
+	genres := make([]string, 0, len(anime.Genres))
+	for _, genre := range anime.Genres {
+		genres = append(genres, genre.Name)
+	}
+
+	titles := append([]string{anime.Title, anime.TitleEnglish, anime.TitleJapanese}, anime.TitleSynonyms...)
+
+	year := anime.Year
+	if year == 0 {
+		year = parseYearFromDate(anime.Aired.From)
+	}
+
+	return &ParsedFilm{
+		Film: apiModels.Film{
+			ID:          anime.ID,
+			Title:       firstNonEmpty(title, anime.TitleEnglish, "Unknown"),
+			Year:        year,
+			Description: anime.Synopsis,
+			Rating:      utils.Round(anime.Score),
+			ImageURL:    firstNonEmpty(anime.Images.JPG.LargeImageURL, anime.Images.JPG.ImageURL),
+			URL:         fmt.Sprintf("%s/anime/%d", myAnimeListSiteURL, anime.ID),
+		},
+		Metadata: FilmMetadata{
+			AlternativeTitles: titles,
+			Genres:            genres,
+			Runtime:           parseRuntime(anime.Duration),
+			Episodes:          anime.Episodes,
+		},
+	}
+}
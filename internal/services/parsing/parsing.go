@@ -4,59 +4,153 @@ import (
 	"fmt"
 	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"net/url"
 	"strings"
 )
 
-// supportedServices contains a list of supported external services for film parsing.
-var supportedServices = []string{
-	"imdb",
-	"kinopoisk",
-	"rezka",
-	"kinoafisha",
-	"youtube",
+// FilmSource is an external service that films can be parsed from by URL.
+// Sources register themselves with Register, usually from an init function in their own file.
+type FilmSource interface {
+	// Name returns the name of the service shown to users in the list of supported services.
+	Name() string
+
+	// MatchHost reports whether the service serves pages on the host.
+	// The host is lowercase and has no "www." prefix.
+	MatchHost(host string) bool
+
+	// ExtractID returns the ID of the film on the service.
+	ExtractID(u *url.URL) (string, error)
+
+	// Canonicalize returns the canonical URL of the film page, stripped of tracking parameters and fragments.
+	Canonicalize(u *url.URL) (*url.URL, error)
+
+	// Fetch fetches the film and its metadata from the service by its canonical URL.
+	Fetch(app models.App, session *models.Session, u *url.URL) (*ParsedFilm, error)
 }
 
-// GetFilmByURL parses a film from a given URL based on the supported service.
-// It determines the service from the URL and delegates parsing to the corresponding function.
-func GetFilmByURL(app models.App, session *models.Session, url string) (*apiModels.Film, error) {
-	switch {
-	case strings.Contains(url, supportedServices[0]):
-		// Parse film from IMDB.
-		return GetFilmFromIMDB(app, session, url)
+// sources contains the registered film sources in registration order.
+var sources []FilmSource
 
-	case strings.Contains(url, supportedServices[1]):
-		// Parse film from Kinopoisk.
-		return GetFilmFromKinopoisk(session, url)
+// Register adds a film source to the registry.
+// It panics if a source with the same name is already registered.
+func Register(source FilmSource) {
+	for _, registered := range sources {
+		if registered.Name() == source.Name() {
+			panic(fmt.Sprintf("parsing: film source %q registered twice", source.Name()))
+		}
+	}
+	sources = append(sources, source)
+}
 
-	case strings.Contains(url, supportedServices[2]):
-		// Parse film from Rezka.
-		return GetFilmFromRezka(session, url)
+// Sources returns the registered film sources in registration order.
+func Sources() []FilmSource {
+	return append([]FilmSource(nil), sources...)
+}
 
-	case strings.Contains(url, supportedServices[3]) && strings.Contains(url, "movies"):
-		// Parse film from Kinoafisha (movies section).
-		return GetFilmFromKinoafisha(session, url)
+// FindSource returns the film source serving the URL together with the parsed URL.
+// URLs no registered source serves are handled by the generic source, which parses film data from any page.
+func FindSource(rawURL string) (FilmSource, *url.URL, error) {
+	u, err := parseURL(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	case strings.Contains(url, supportedServices[3]) && strings.Contains(url, "series"):
-		// Parse series from Kinoafisha (series section).
-		return GetSeriesFromKinoafisha(session, url)
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	for _, source := range sources {
+		if source.MatchHost(host) {
+			return source, u, nil
+		}
+	}
+	return genericSource{}, u, nil
+}
+
+// GetFilmByURL parses a film from a given URL based on the supported service.
+// It finds the source serving the URL, canonicalizes the URL and delegates fetching to the source.
+// The metadata of the film is stored in its genre and description; see ParsedFilm.ToFilm.
+// Unless the source sets it, the URL of the returned film is the canonical one.
+func GetFilmByURL(app models.App, session *models.Session, rawURL string) (*apiModels.Film, error) {
+	source, u, err := FindSource(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	canonical, err := source.Canonicalize(u)
+	if err != nil {
+		return nil, err
+	}
 
-	case strings.Contains(url, supportedServices[4]) || strings.Contains(url, "youtu.be"):
-		// Parse film from YouTube or youtu.be links.
-		return GetFilmFromYoutube(app, session, url)
+	parsed, err := source.Fetch(app, session, canonical)
+	if err != nil {
+		return nil, err
+	}
 
-	default:
-		// Return an error if the URL is not supported.
-		return nil, fmt.Errorf("unsupported URL")
+	film := parsed.ToFilm(session)
+	if film.URL == "" {
+		film.URL = canonical.String()
 	}
+	return film, nil
 }
 
 // GetSupportedServicesInline returns a comma-separated string of supported services.
 // This can be used to inform users about the platforms they can provide URLs from.
 func GetSupportedServicesInline() string {
-	return strings.Join(supportedServices, ", ")
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.Name()
+	}
+	return strings.Join(names, ", ")
+}
+
+// IsSupported checks if the given URL belongs to one of the registered services rather than an arbitrary site.
+func IsSupported(rawURL string) bool {
+	source, _, err := FindSource(rawURL)
+	if err != nil {
+		return false
+	}
+	_, isGeneric := source.(genericSource)
+	return !isGeneric
 }
 
 // IsKinopoisk checks if the given URL belongs to the Kinopoisk service.
-func IsKinopoisk(url string) bool {
-	return strings.Contains(url, supportedServices[1])
+func IsKinopoisk(rawURL string) bool {
+	source, _, err := FindSource(rawURL)
+	return err == nil && source.Name() == kinopoiskName
+}
+
+// parseURL parses a URL sent by a user, who may omit the scheme or send only an IMDb ID.
+func parseURL(rawURL string) (*url.URL, error) {
+	rawURL = strings.TrimSpace(expandIMDbID(rawURL))
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("URL has no host")
+	}
+	return u, nil
+}
+
+// matchDomain reports whether the host is one of the domains or their subdomains.
+func matchDomain(host string, domains ...string) bool {
+	for _, domain := range domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathSegments returns the non-empty segments of the URL path.
+func pathSegments(u *url.URL) []string {
+	var segments []string
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
 }
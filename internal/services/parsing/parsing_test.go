@@ -0,0 +1,28 @@
+package parsing
+
+import (
+	"github.com/k4sper1love/watchlist-bot/pkg/translator"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// Film descriptions contain translated labels, so the locales of the module are loaded.
+	if err := translator.Init(filepath.Join("..", "..", "..", "locales")); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+// openFixture opens an HTML fixture from the testdata directory.
+func openFixture(t *testing.T, name string) *os.File {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to open fixture: %v", err)
+	}
+	t.Cleanup(func() { _ = file.Close() })
+	return file
+}
@@ -1,24 +1,68 @@
 package parsing
 
 import (
+	"fmt"
 	"github.com/PuerkitoBio/goquery"
-	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
 	"github.com/k4sper1love/watchlist-bot/internal/services/client"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"github.com/k4sper1love/watchlist-bot/pkg/httpclient"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 )
 
-// GetFilmFromRezka fetches film details from the Rezka website using the provided URL.
-// It sends an HTTP GET request to the URL and parses the HTML response into an `models.Film` object.
-func GetFilmFromRezka(session *models.Session, url string) (*apiModels.Film, error) {
+// rezkaSource parses films from Rezka pages.
+type rezkaSource struct{}
+
+func init() {
+	Register(rezkaSource{})
+}
+
+// Name returns the name of the Rezka source.
+func (rezkaSource) Name() string {
+	return "rezka"
+}
+
+// MatchHost reports whether the host is one of the Rezka mirrors, such as hdrezka.ag or rezka.ag.
+func (rezkaSource) MatchHost(host string) bool {
+	return strings.Contains(host, "rezka")
+}
+
+// ExtractID extracts the Rezka ID from the page name, such as "1234" in "/films/drama/1234-title-2020.html".
+func (rezkaSource) ExtractID(u *url.URL) (string, error) {
+	segments := pathSegments(u)
+	if len(segments) == 0 {
+		return "", fmt.Errorf("id not found")
+	}
+
+	id, _, _ := strings.Cut(segments[len(segments)-1], "-")
+	if _, err := strconv.Atoi(id); err != nil {
+		return "", fmt.Errorf("id not found")
+	}
+	return id, nil
+}
+
+// Canonicalize returns the page URL without query parameters and fragments.
+// The mirror is kept because not every mirror is reachable in every region.
+func (s rezkaSource) Canonicalize(u *url.URL) (*url.URL, error) {
+	if _, err := s.ExtractID(u); err != nil {
+		return nil, err
+	}
+	return &url.URL{Scheme: "https", Host: strings.ToLower(u.Host), Path: u.Path}, nil
+}
+
+// Fetch fetches film details from the Rezka page.
+// It sends an HTTP GET request to the URL and parses the HTML response into a `ParsedFilm` object.
+func (rezkaSource) Fetch(app models.App, session *models.Session, u *url.URL) (*ParsedFilm, error) {
 	resp, err := client.Do(
 		&client.CustomRequest{
+			Context:            app.Context(),
+			Service:            httpclient.External,
 			Method:             http.MethodGet, // HTTP GET method for fetching data.
-			URL:                url,
+			URL:                u.String(),
 			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
 			TelegramID:         session.TelegramID,
 		},
@@ -28,7 +72,7 @@ func GetFilmFromRezka(session *models.Session, url string) (*apiModels.Film, err
 	}
 	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
 
-	var film apiModels.Film
+	var film ParsedFilm
 	if err = parseFilmFromRezka(&film, resp.Body); err != nil {
 		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
 		return nil, err
@@ -37,20 +81,31 @@ func GetFilmFromRezka(session *models.Session, url string) (*apiModels.Film, err
 	return &film, err
 }
 
-// parseFilmFromRezka parses film details from the Rezka HTML document into an `models.Film` object.
-func parseFilmFromRezka(dest *apiModels.Film, data io.Reader) error {
+// parseFilmFromRezka parses film details and metadata from the Rezka HTML document into a `ParsedFilm` object.
+func parseFilmFromRezka(dest *ParsedFilm, data io.Reader) error {
 	doc, err := goquery.NewDocumentFromReader(data)
 	if err != nil {
 		return err
 	}
 
 	// Extract film details from the HTML document.
-	dest.Title = getTextOrDefault(doc, ".b-post__title", "Unknown")                                  // Default title is "Unknown".
-	dest.Year = parseYearFromRezka(getTextOrDefault(doc, "a[href*='/year/']", "0"))                  // Extract and parse the year.
-	dest.Genre = getGenreFromRezka(doc)                                                              // Extract the first genre from the list.
-	dest.Description = getTextOrDefault(doc, ".b-post__description_text", "")                        // Default description is an empty string.
-	dest.Rating = parseRatingFromRezka(getTextOrDefault(doc, ".b-post__info_rates.imdb .bold", "0")) // Parse the rating.
-	dest.ImageURL = doc.Find(".b-sidecover a").AttrOr("href", "")                                    // Extract the image URL.
+	dest.Film.Title = getTextOrDefault(doc, ".b-post__title", "Unknown")                                  // Default title is "Unknown".
+	dest.Film.Year = parseYearFromRezka(getTextOrDefault(doc, "a[href*='/year/']", "0"))                  // Extract and parse the year.
+	dest.Film.Description = getTextOrDefault(doc, ".b-post__description_text", "")                        // Default description is an empty string.
+	dest.Film.Rating = parseRatingFromRezka(getTextOrDefault(doc, ".b-post__info_rates.imdb .bold", "0")) // Parse the rating.
+	dest.Film.ImageURL = doc.Find(".b-sidecover a").AttrOr("href", "")                                    // Extract the image URL.
+
+	// Extract the metadata from the information table.
+	dest.Metadata = FilmMetadata{
+		OriginalTitle: getTextOrDefault(doc, ".b-post__origtitle", ""),
+		Genres:        getInfoFromRezka(doc, "Жанр"),
+		Runtime:       parseRuntime(strings.Join(getInfoFromRezka(doc, "Время"), "")),
+		Countries:     getInfoFromRezka(doc, "Страна"),
+		Directors:     getInfoFromRezka(doc, "Режиссер"),
+		Cast:          getInfoFromRezka(doc, "В ролях"),
+		AgeRating:     parseAgeRatingFromRezka(getInfoFromRezka(doc, "Возраст")),
+		Seasons:       getSeasonsFromRezka(doc),
+	}
 
 	return nil
 }
@@ -68,15 +123,47 @@ func parseRatingFromRezka(ratingStr string) float64 {
 	return rating
 }
 
-// getGenreFromRezka extracts the first genre from the Rezka HTML document.
-func getGenreFromRezka(doc *goquery.Document) string {
-	var genre string
-	doc.Find(".b-post__info tr").Each(func(i int, s *goquery.Selection) {
-		label := strings.TrimSpace(s.Find("td.l").Text()) // Extract the label text.
-		if strings.Contains(label, "Жанр") {              // Check if the label contains "Жанр".
-			genre = strings.Split(strings.TrimSpace(s.Find("td").Last().Text()), ",")[0] // Extract the first genre.
+// getInfoFromRezka extracts the comma-separated values of the row of the Rezka information table with the label,
+// such as the genres in the "Жанр" row.
+func getInfoFromRezka(doc *goquery.Document, label string) []string {
+	var values []string
+	doc.Find(".b-post__info tr").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		if !strings.Contains(strings.TrimSpace(s.Find("td.l").Text()), label) { // Check if the row has the label.
+			return true
+		}
+
+		for _, value := range strings.Split(s.Find("td").Last().Text(), ",") {
+			if value = strings.TrimSpace(value); value != "" && value != "и другие" { // Skip the "and others" suffix of the cast.
+				values = append(values, value)
+			}
+		}
+		return false
+	})
+	return values
+}
+
+// getSeasonsFromRezka extracts the number of episodes in each season of a series from the Rezka episode list,
+// where each episode has the numbers of its season and of itself within the season.
+func getSeasonsFromRezka(doc *goquery.Document) []int {
+	var seasons []int
+	doc.Find(".b-simple_episode__item").Each(func(i int, s *goquery.Selection) {
+		season, _ := strconv.Atoi(s.AttrOr("data-season_id", ""))
+		episode, _ := strconv.Atoi(s.AttrOr("data-episode_id", ""))
+		if season < 1 || season > maxSeasons || episode < 1 {
 			return
 		}
+
+		seasons = append(seasons, make([]int, max(0, season-len(seasons)))...)
+		seasons[season-1] = max(seasons[season-1], episode)
 	})
-	return genre
+	return seasons
+}
+
+// parseAgeRatingFromRezka extracts the age rating, such as "18+" in "18+ только для взрослых", from the "Возраст" row.
+func parseAgeRatingFromRezka(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	rating, _, _ := strings.Cut(values[0], " ")
+	return rating
 }
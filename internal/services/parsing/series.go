@@ -0,0 +1,285 @@
+package parsing
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxSeasons        = 100  // Maximum number of seasons of a series.
+	maxSeasonEpisodes = 5000 // Maximum number of episodes in a season.
+)
+
+// numberRegex matches the numbers in the user input of seasons or a watched episode, such as "10, 10, 8" or "S2E5".
+var numberRegex = regexp.MustCompile(`\d+`)
+
+// Series is the season structure of a series and the progress of watching it.
+// Both are kept in the metadata block of the film description, as the film model has no fields for them.
+type Series struct {
+	Seasons  []int // Number of episodes in each season, or 0 if unknown; the length is the number of seasons.
+	Episodes int   // Total number of episodes, used if the episodes of the seasons are unknown.
+	Season   int   // Season of the last watched episode, or 0 if no episode has been watched.
+	Episode  int   // Number of the last watched episode in its season.
+}
+
+// ParseSeries reads the series details from the metadata block of a film description.
+// It reports false if the description has none of them, so the film is not tracked as a series.
+func ParseSeries(description string) (*Series, bool) {
+	_, fields := SplitDescription(description)
+
+	var series Series
+	var found bool
+	for _, field := range fields {
+		switch field.Key {
+		case "episodes":
+			series.Episodes, _ = strconv.Atoi(field.Value)
+		case "seasons":
+			series.Seasons = parseSeasons(field.Value)
+		case "watchedEpisode":
+			series.Season, series.Episode = parseEpisode(field.Value)
+		default:
+			continue
+		}
+		found = true
+	}
+	return &series, found
+}
+
+// ParseSeasonsInput parses the user input of the seasons of a series as the numbers of episodes in each season,
+// such as "10, 10, 8". A single number is the number of episodes of a series with one season.
+func ParseSeasonsInput(input string) ([]int, bool) {
+	numbers := numberRegex.FindAllString(input, -1)
+	if len(numbers) == 0 || len(numbers) > maxSeasons {
+		return nil, false
+	}
+
+	seasons := make([]int, len(numbers))
+	for i, number := range numbers {
+		episodes, err := strconv.Atoi(number)
+		if err != nil || episodes < 1 || episodes > maxSeasonEpisodes {
+			return nil, false
+		}
+		seasons[i] = episodes
+	}
+	return seasons, true
+}
+
+// SetSeasons sets the episodes of the seasons, keeping the watched episode if it is still within the series.
+func (s *Series) SetSeasons(seasons []int) {
+	s.Seasons = seasons
+	s.Episodes = 0
+	if !s.isValidEpisode(s.Season, s.Episode) {
+		s.Season, s.Episode = 0, 0
+	}
+}
+
+// SetWatchedInput sets the last watched episode from the user input, such as "2 5" or "S2E5" for the fifth episode
+// of the second season. A single number is the number of the episode counted from the start of the series,
+// and 0 means no episode has been watched. It reports false if the episode is not within the series.
+func (s *Series) SetWatchedInput(input string) bool {
+	numbers := numberRegex.FindAllString(input, -1)
+	if len(numbers) == 0 || len(numbers) > 2 {
+		return false
+	}
+
+	values := make([]int, len(numbers))
+	for i, number := range numbers {
+		value, err := strconv.Atoi(number)
+		if err != nil {
+			return false
+		}
+		values[i] = value
+	}
+
+	season, episode := 1, values[0]
+	if len(values) == 2 {
+		season, episode = values[0], values[1]
+	} else if episode == 0 {
+		s.Season, s.Episode = 0, 0
+		return true
+	} else if s.hasSeasonEpisodes() {
+		season, episode = s.locateEpisode(episode)
+	}
+
+	if !s.isValidEpisode(season, episode) {
+		return false
+	}
+	s.Season, s.Episode = season, episode
+	return true
+}
+
+// NextEpisode marks the episode after the last watched one as watched.
+// After the last episode of a season, the first episode of the next season follows,
+// and nothing changes once the last episode of the series has been watched.
+func (s *Series) NextEpisode() {
+	if s.IsFinished() {
+		return
+	}
+	if s.Season == 0 {
+		s.Season = 1
+	}
+
+	s.Episode++
+	if s.Season < len(s.Seasons) && s.Seasons[s.Season-1] > 0 && s.Episode > s.Seasons[s.Season-1] {
+		s.Season++
+		s.Episode = 1
+	}
+}
+
+// Total returns the total number of episodes, or 0 if it is unknown.
+func (s *Series) Total() int {
+	if !s.hasSeasonEpisodes() {
+		return s.Episodes
+	}
+
+	var total int
+	for _, episodes := range s.Seasons {
+		total += episodes
+	}
+	return total
+}
+
+// Watched returns the number of watched episodes counted from the start of the series.
+// It reports false if the episodes of the seasons before the watched one are unknown.
+func (s *Series) Watched() (int, bool) {
+	if s.Season <= 1 {
+		return s.Episode, true
+	}
+	if !s.hasSeasonEpisodes() || s.Season > len(s.Seasons) {
+		return 0, false
+	}
+
+	watched := s.Episode
+	for _, episodes := range s.Seasons[:s.Season-1] {
+		watched += episodes
+	}
+	return watched, true
+}
+
+// IsFinished reports whether the last episode of the series has been watched.
+func (s *Series) IsFinished() bool {
+	watched, ok := s.Watched()
+	total := s.Total()
+	return ok && total > 0 && watched >= total
+}
+
+// FormatEpisode formats the last watched episode, such as "S2E5", or returns an empty string if none was watched.
+func (s *Series) FormatEpisode() string {
+	if s.Season == 0 {
+		return ""
+	}
+	return fmt.Sprintf("S%dE%d", s.Season, s.Episode)
+}
+
+// SetDescription returns the description with the series details of its metadata block replaced by those of the series.
+// The labels of the block are written in the language.
+func (s *Series) SetDescription(lang, description string) string {
+	text, fields := SplitDescription(description)
+
+	values := map[string]string{}
+	for _, field := range fields {
+		values[field.Key] = field.Value
+	}
+
+	values["episodes"] = ""
+	if total := s.Total(); total > 0 {
+		values["episodes"] = strconv.Itoa(total)
+	}
+	values["seasons"] = formatSeasons(s.Seasons)
+	values["watchedEpisode"] = s.FormatEpisode()
+
+	var updated []MetadataField
+	for _, field := range metadataFields {
+		if value := values[field.key]; value != "" {
+			updated = append(updated, MetadataField{Marker: field.marker, Key: field.key, Value: value})
+		}
+	}
+	return buildDescription(text, FormatMetadata(lang, updated))
+}
+
+// hasSeasonEpisodes reports whether the numbers of episodes of all seasons are known.
+func (s *Series) hasSeasonEpisodes() bool {
+	for _, episodes := range s.Seasons {
+		if episodes <= 0 {
+			return false
+		}
+	}
+	return len(s.Seasons) > 0
+}
+
+// isValidEpisode reports whether the episode of the season is within the known structure of the series.
+func (s *Series) isValidEpisode(season, episode int) bool {
+	switch {
+	case season == 0 && episode == 0:
+		return true
+	case season < 1 || episode < 1:
+		return false
+	case len(s.Seasons) > 0 && season > len(s.Seasons):
+		return false
+	case len(s.Seasons) > 0 && s.Seasons[season-1] > 0:
+		return episode <= s.Seasons[season-1]
+	case len(s.Seasons) == 0 && season == 1 && s.Episodes > 0:
+		return episode <= s.Episodes
+	default:
+		return true
+	}
+}
+
+// locateEpisode converts the number of an episode counted from the start of the series to its season and episode.
+// Episodes past the end of the series are left in the last season, so they are rejected as invalid.
+func (s *Series) locateEpisode(number int) (int, int) {
+	for i, episodes := range s.Seasons {
+		if number <= episodes || i == len(s.Seasons)-1 {
+			return i + 1, number
+		}
+		number -= episodes
+	}
+	return 1, number
+}
+
+// formatSeasons formats the seasons as their number followed by the episodes of each season, such as "3 (10, 10, 8)".
+// Only the number of seasons is written if the episodes of some seasons are unknown.
+func formatSeasons(seasons []int) string {
+	if len(seasons) == 0 {
+		return ""
+	}
+
+	series := Series{Seasons: seasons}
+	if !series.hasSeasonEpisodes() {
+		return strconv.Itoa(len(seasons))
+	}
+
+	episodes := make([]string, len(seasons))
+	for i, count := range seasons {
+		episodes[i] = strconv.Itoa(count)
+	}
+	return fmt.Sprintf("%d (%s)", len(seasons), strings.Join(episodes, ", "))
+}
+
+// parseSeasons parses the seasons formatted by formatSeasons.
+func parseSeasons(value string) []int {
+	count, episodes, _ := strings.Cut(value, "(")
+	number, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil || number < 1 || number > maxSeasons {
+		return nil
+	}
+
+	seasons := make([]int, number)
+	for i, episode := range strings.Split(strings.TrimSuffix(strings.TrimSpace(episodes), ")"), ",") {
+		if i < number {
+			seasons[i], _ = strconv.Atoi(strings.TrimSpace(episode))
+		}
+	}
+	return seasons
+}
+
+// parseEpisode parses the watched episode formatted by FormatEpisode, returning zeros if it cannot be parsed.
+func parseEpisode(value string) (int, int) {
+	var season, episode int
+	if _, err := fmt.Sscanf(value, "S%dE%d", &season, &episode); err != nil || season < 1 || episode < 1 {
+		return 0, 0
+	}
+	return season, episode
+}
@@ -0,0 +1,63 @@
+package parsing
+
+import (
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"testing"
+)
+
+func TestSeriesProgressThroughSeasons(t *testing.T) {
+	parsed := &ParsedFilm{
+		Film:     apiModels.Film{Title: "Dark", Description: "A missing child sets four families on a hunt."},
+		Metadata: FilmMetadata{Seasons: []int{2, 1}, Countries: []string{"Germany"}},
+	}
+	description := parsed.ToFilm(&models.Session{Lang: "en"}).Description
+
+	series, ok := ParseSeries(description)
+	if !ok || series.Total() != 3 || series.FormatEpisode() != "" {
+		t.Fatalf("series = %+v, want 3 episodes in 2 seasons and nothing watched", series)
+	}
+
+	var episodes []string
+	for !series.IsFinished() {
+		series.NextEpisode()
+		description = series.SetDescription("en", description)
+		series, _ = ParseSeries(description)
+		episodes = append(episodes, series.FormatEpisode())
+	}
+	if len(episodes) != 3 || episodes[1] != "S1E2" || episodes[2] != "S2E1" {
+		t.Fatalf("episodes = %v, want S1E1, S1E2 and S2E1", episodes)
+	}
+	if series.NextEpisode(); series.FormatEpisode() != "S2E1" {
+		t.Errorf("episode after the end = %s, want S2E1 kept", series.FormatEpisode())
+	}
+
+	text, fields := SplitDescription(description)
+	if text != "A missing child sets four families on a hunt." || len(fields) != 4 || fields[3].Key != "countries" {
+		t.Errorf("description = %q, want the text and the other metadata kept", description)
+	}
+}
+
+func TestSeriesSetWatchedInput(t *testing.T) {
+	tests := []struct {
+		input   string
+		ok      bool
+		episode string
+	}{
+		{"2 5", true, "S2E5"},
+		{"S1E10", true, "S1E10"},
+		{"13", true, "S2E3"},
+		{"0", true, ""},
+		{"3 1", false, ""},
+		{"1 11", false, ""},
+		{"29", false, ""},
+		{"next", false, ""},
+	}
+
+	for _, test := range tests {
+		series := Series{Seasons: []int{10, 10}}
+		if ok := series.SetWatchedInput(test.input); ok != test.ok || (ok && series.FormatEpisode() != test.episode) {
+			t.Errorf("SetWatchedInput(%q) = %v, %s; want %v, %s", test.input, ok, series.FormatEpisode(), test.ok, test.episode)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package parsing
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/k4sper1love/watchlist-api/pkg/filters"
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/client"
+	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"github.com/k4sper1love/watchlist-bot/pkg/httpclient"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	shikimoriSiteURL   = "https://shikimori.one" // Base URL of the Shikimori website and API.
+	shikimoriUserAgent = "watchlist-bot"         // User agent Shikimori requires API clients to identify themselves with.
+	shikimoriMaxLimit  = 50                      // Maximum number of results on a Shikimori search page.
+)
+
+// shikimoriMarkup matches the BBCode tags of Shikimori descriptions, such as "[character=1]" and "[/character]".
+var shikimoriMarkup = regexp.MustCompile(`\[/?[a-z_]+(=[^\]]*)?\]`)
+
+// shikimoriAnime represents an anime in Shikimori search results and details.
+type shikimoriAnime struct {
+	ID            int              `json:"id"`             // Shikimori ID.
+	Name          string           `json:"name"`           // Romanized title.
+	Russian       string           `json:"russian"`        // Russian title.
+	English       []string         `json:"english"`        // English titles, returned in details.
+	Japanese      []string         `json:"japanese"`       // Japanese titles, returned in details.
+	Synonyms      []string         `json:"synonyms"`       // Other titles, returned in details.
+	Score         string           `json:"score"`          // Average user rating out of 10.
+	Episodes      int              `json:"episodes"`       // Number of episodes, or 0 if unknown.
+	EpisodesAired int              `json:"episodes_aired"` // Number of aired episodes of an ongoing anime.
+	Duration      int              `json:"duration"`       // Runtime of an episode in minutes, returned in details.
+	AiredOn       string           `json:"aired_on"`       // First air date (YYYY-MM-DD).
+	Description   string           `json:"description"`    // Russian description with BBCode markup, returned in details.
+	Image         shikimoriImage   `json:"image"`          // Poster paths.
+	Genres        []shikimoriGenre `json:"genres"`         // Genres, returned in details.
+}
+
+// shikimoriImage represents the poster paths of a Shikimori anime.
+type shikimoriImage struct {
+	Original string `json:"original"` // Path of the full-size poster relative to the site URL.
+}
+
+// shikimoriGenre represents a Shikimori genre.
+type shikimoriGenre struct {
+	Name    string `json:"name"`    // English genre name.
+	Russian string `json:"russian"` // Russian genre name.
+}
+
+// shikimoriSource parses anime from Shikimori pages through the Shikimori API.
+type shikimoriSource struct{}
+
+func init() {
+	Register(shikimoriSource{})
+}
+
+// Name returns the name of the Shikimori source.
+func (shikimoriSource) Name() string {
+	return "shikimori"
+}
+
+// MatchHost reports whether the host belongs to Shikimori or one of its mirrors.
+func (shikimoriSource) MatchHost(host string) bool {
+	return matchDomain(host, "shikimori.one", "shikimori.me", "shikimori.org", "shiki.one")
+}
+
+// ExtractID extracts the Shikimori ID from an anime page URL, such as "1" in "/animes/z1-cowboy-bebop".
+// Letters before the ID mark the state of the page on the site and are skipped.
+func (shikimoriSource) ExtractID(u *url.URL) (string, error) {
+	segments := pathSegments(u)
+	if len(segments) < 2 || segments[0] != "animes" {
+		return "", fmt.Errorf("invalid Shikimori URL: %s", u)
+	}
+
+	id, _, _ := strings.Cut(strings.TrimLeft(segments[1], "abcdefghijklmnopqrstuvwxyz"), "-")
+	if _, err := strconv.Atoi(id); err != nil {
+		return "", fmt.Errorf("invalid Shikimori URL: %s", u)
+	}
+	return id, nil
+}
+
+// Canonicalize returns the URL of the anime page on the main Shikimori domain without the title slug.
+func (s shikimoriSource) Canonicalize(u *url.URL) (*url.URL, error) {
+	id, err := s.ExtractID(u)
+	if err != nil {
+		return nil, err
+	}
+	return url.Parse(fmt.Sprintf("%s/animes/%s", shikimoriSiteURL, id))
+}
+
+// Fetch fetches anime details from the Shikimori API.
+func (s shikimoriSource) Fetch(app models.App, session *models.Session, u *url.URL) (*ParsedFilm, error) {
+	id, err := s.ExtractID(u)
+	if err != nil {
+		utils.LogParseFromURLError(session.TelegramID, "failed to parse ID", err, u.String())
+		return nil, err
+	}
+
+	var film *ParsedFilm
+	err = getDataFromShikimori(app, session, "/api/animes/"+id, nil, func(data io.Reader) (err error) {
+		film, err = parseAnimeFromShikimori(session, data)
+		return err
+	})
+	return film, err
+}
+
+// GetAnimeFromShikimori searches anime on Shikimori by the session's title, page and page size.
+// Shikimori does not report the number of results, so the last known page is the next one while there are more results.
+func GetAnimeFromShikimori(app models.App, session *models.Session) ([]apiModels.Film, *filters.Metadata, error) {
+	state := session.FilmsState
+	pageSize := min(max(state.PageSize, 1), shikimoriMaxLimit)
+
+	query := url.Values{
+		"search": {state.Title},
+		"page":   {strconv.Itoa(state.CurrentPage)},
+		"limit":  {strconv.Itoa(pageSize)},
+	}
+
+	var films []apiModels.Film
+	var hasNext bool
+	err := getDataFromShikimori(app, session, "/api/animes", query, func(data io.Reader) (err error) {
+		films, hasNext, err = parseAnimeListFromShikimori(session, data, pageSize)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	totalRecords := (state.CurrentPage-1)*pageSize + len(films)
+	if hasNext {
+		totalRecords++ // Makes the next page reachable.
+	}
+	metadata := filters.CalculateMetadata(totalRecords, state.CurrentPage, pageSize)
+
+	return films, &metadata, nil
+}
+
+// getDataFromShikimori sends an HTTP GET request to the Shikimori API and passes the response body to parse.
+func getDataFromShikimori(app models.App, session *models.Session, path string, query url.Values, parse func(io.Reader) error) error {
+	apiURL := shikimoriSiteURL + path
+	if len(query) > 0 {
+		apiURL += "?" + query.Encode()
+	}
+
+	resp, err := client.Do(
+		&client.CustomRequest{
+			Context:            app.Context(),
+			Service:            httpclient.External,
+			HeaderType:         client.HeaderUserAgent, // Shikimori rejects requests without a user agent.
+			HeaderValue:        shikimoriUserAgent,
+			Method:             http.MethodGet, // HTTP GET method for fetching data.
+			URL:                apiURL,
+			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
+			TelegramID:         session.TelegramID,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
+
+	if err = parse(resp.Body); err != nil {
+		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
+		return err
+	}
+	return nil
+}
+
+// parseAnimeFromShikimori parses anime details from the Shikimori API response into a `ParsedFilm` object.
+func parseAnimeFromShikimori(session *models.Session, data io.Reader) (*ParsedFilm, error) {
+	var anime shikimoriAnime
+	if err := json.NewDecoder(data).Decode(&anime); err != nil {
+		return nil, err
+	}
+	if anime.ID == 0 {
+		return nil, fmt.Errorf("anime not found in the response")
+	}
+	return parseFilmFromShikimori(session, &anime), nil
+}
+
+// parseAnimeListFromShikimori parses Shikimori search results into `models.Film` objects.
+// Shikimori returns one result more than the limit if there is a next page, so it is dropped and reported instead.
+func parseAnimeListFromShikimori(session *models.Session, data io.Reader, limit int) ([]apiModels.Film, bool, error) {
+	var list []shikimoriAnime
+	if err := json.NewDecoder(data).Decode(&list); err != nil {
+		return nil, false, err
+	}
+
+	hasNext := len(list) > limit
+	if hasNext {
+		list = list[:limit]
+	}
+
+	films := make([]apiModels.Film, 0, len(list))
+	for i := range list {
+		films = append(films, *parseFilmFromShikimori(session, &list[i]).ToFilm(session))
+	}
+	return films, hasNext, nil
+}
+
+// parseFilmFromShikimori converts a Shikimori anime into a `ParsedFilm` object.
+// Russian titles and genres are used for all languages except English, as Shikimori only has Russian translations.
+func parseFilmFromShikimori(session *models.Session, anime *shikimoriAnime) *ParsedFilm {
+	title, otherTitle := anime.Russian, anime.Name
+	if session.Lang == "en" {
+		title, otherTitle = anime.Name, anime.Russian
+	}
+
+	genres := make([]string, 0, len(anime.Genres))
+	for _, genre := range anime.Genres {
+		if session.Lang == "en" {
+			genres = append(genres, firstNonEmpty(genre.Name, genre.Russian))
+		} else {
+			genres = append(genres, firstNonEmpty(genre.Russian, genre.Name))
+		}
+	}
+
+	titles := append([]string{otherTitle}, anime.English...)
+	titles = append(titles, anime.Japanese...)
+	titles = append(titles, anime.Synonyms...)
+
+	episodes := anime.Episodes
+	if episodes == 0 {
+		episodes = anime.EpisodesAired // Ongoing anime may have no planned number of episodes.
+	}
+
+	score, _ := strconv.ParseFloat(anime.Score, 64)
+
+	film := &ParsedFilm{
+		Film: apiModels.Film{
+			ID:          anime.ID,
+			Title:       firstNonEmpty(title, otherTitle, "Unknown"),
+			Year:        parseYearFromDate(anime.AiredOn),
+			Description: cleanShikimoriDescription(anime.Description),
+			Rating:      utils.Round(score),
+			URL:         fmt.Sprintf("%s/animes/%d", shikimoriSiteURL, anime.ID),
+		},
+		Metadata: FilmMetadata{
+			AlternativeTitles: titles,
+			Genres:            genres,
+			Runtime:           anime.Duration,
+			Episodes:          episodes,
+		},
+	}
+	if path := anime.Image.Original; path != "" && !strings.Contains(path, "missing") {
+		film.Film.ImageURL = shikimoriSiteURL + path
+	}
+	return film
+}
+
+// cleanShikimoriDescription removes the BBCode markup from a Shikimori description, keeping the text inside the tags.
+func cleanShikimoriDescription(description string) string {
+	return strings.TrimSpace(shikimoriMarkup.ReplaceAllString(description, ""))
+}
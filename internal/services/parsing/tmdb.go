@@ -0,0 +1,372 @@
+package parsing
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/k4sper1love/watchlist-api/pkg/filters"
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/client"
+	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"github.com/k4sper1love/watchlist-bot/pkg/httpclient"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	tmdbAPIURL     = "https://api.themoviedb.org/3"    // Base URL of the TMDB API.
+	tmdbSiteURL    = "https://www.themoviedb.org"      // Base URL of the TMDB website.
+	tmdbPosterURL  = "https://image.tmdb.org/t/p/w500" // Base URL of TMDB posters.
+	tmdbPageSize   = 20                                // Number of results on a TMDB search page.
+	tmdbMaxPages   = 500                               // Maximum search page returned by TMDB.
+	tmdbKindMovie  = "movie"                           // TMDB media type of films.
+	tmdbKindSeries = "tv"                              // TMDB media type of series.
+
+	tmdbGenresRetryDelay = time.Minute // Time after a failed request for genres before they are requested again.
+)
+
+// tmdbMedia represents a film or series in TMDB search results and details.
+type tmdbMedia struct {
+	ID            int         `json:"id"`             // TMDB ID.
+	Title         string      `json:"title"`          // Localized title of a film.
+	Name          string      `json:"name"`           // Localized title of a series.
+	OriginalTitle string      `json:"original_title"` // Original title of a film.
+	OriginalName  string      `json:"original_name"`  // Original title of a series.
+	Overview      string      `json:"overview"`       // Localized description.
+	PosterPath    string      `json:"poster_path"`    // Path of the poster relative to the image base URL.
+	ReleaseDate   string      `json:"release_date"`   // Release date of a film (YYYY-MM-DD).
+	FirstAirDate  string      `json:"first_air_date"` // First air date of a series (YYYY-MM-DD).
+	VoteAverage   float64     `json:"vote_average"`   // Average user rating out of 10.
+	GenreIDs      []int       `json:"genre_ids"`      // Genre IDs, returned in search results.
+	Genres        []tmdbGenre `json:"genres"`         // Genres, returned in details.
+
+	Runtime             int          `json:"runtime"`              // Runtime of a film in minutes, returned in details.
+	EpisodeRunTime      []int        `json:"episode_run_time"`     // Runtimes of series episodes in minutes, returned in details.
+	NumberOfEpisodes    int          `json:"number_of_episodes"`   // Number of series episodes, returned in details.
+	Seasons             []tmdbSeason `json:"seasons"`              // Seasons of a series, returned in details.
+	ProductionCountries []tmdbGenre  `json:"production_countries"` // Countries of production, returned in details.
+	Credits             tmdbCredits  `json:"credits"`              // Cast and crew, returned in details with credits appended.
+}
+
+// tmdbSeason represents a season of a TMDB series.
+type tmdbSeason struct {
+	SeasonNumber int `json:"season_number"` // Number of the season, or 0 for specials.
+	EpisodeCount int `json:"episode_count"` // Number of episodes in the season.
+}
+
+// tmdbCredits represents the cast and crew of a TMDB film or series.
+type tmdbCredits struct {
+	Cast []struct {
+		Name string `json:"name"` // Name of the actor.
+	} `json:"cast"` // Actors in billing order.
+	Crew []struct {
+		Name string `json:"name"` // Name of the crew member.
+		Job  string `json:"job"`  // Job of the crew member, such as "Director".
+	} `json:"crew"` // Crew members.
+}
+
+// tmdbGenre represents a TMDB genre or another named item, such as a country.
+type tmdbGenre struct {
+	ID   int    `json:"id"`   // Genre ID.
+	Name string `json:"name"` // Localized name.
+}
+
+// tmdbSearchResponse represents a page of TMDB search results.
+type tmdbSearchResponse struct {
+	Page         int         `json:"page"`          // Number of the page.
+	Results      []tmdbMedia `json:"results"`       // Films on the page.
+	TotalPages   int         `json:"total_pages"`   // Number of pages.
+	TotalResults int         `json:"total_results"` // Number of results.
+}
+
+// tmdbGenres caches the names of TMDB film genres keyed by language and genre ID,
+// and the times of the failed requests for them keyed by language.
+var tmdbGenres = struct {
+	names    map[string]map[int]string
+	failedAt map[string]time.Time
+	mu       sync.Mutex
+}{names: make(map[string]map[int]string), failedAt: make(map[string]time.Time)}
+
+// tmdbSource parses films and series from TMDB pages through the TMDB API.
+type tmdbSource struct{}
+
+func init() {
+	Register(tmdbSource{})
+}
+
+// Name returns the name of the TMDB source.
+func (tmdbSource) Name() string {
+	return "tmdb"
+}
+
+// MatchHost reports whether the host belongs to TMDB.
+func (tmdbSource) MatchHost(host string) bool {
+	return matchDomain(host, "themoviedb.org")
+}
+
+// ExtractID extracts the TMDB ID from a movie or series page URL, such as "603" in "/movie/603-the-matrix".
+func (s tmdbSource) ExtractID(u *url.URL) (string, error) {
+	_, id, err := s.parsePath(u)
+	return id, err
+}
+
+// Canonicalize returns the URL of the movie or series page without the title slug.
+func (s tmdbSource) Canonicalize(u *url.URL) (*url.URL, error) {
+	kind, id, err := s.parsePath(u)
+	if err != nil {
+		return nil, err
+	}
+	return url.Parse(fmt.Sprintf("%s/%s/%s", tmdbSiteURL, kind, id))
+}
+
+// Fetch fetches film or series details and credits from the TMDB API in the session language.
+func (s tmdbSource) Fetch(app models.App, session *models.Session, u *url.URL) (*ParsedFilm, error) {
+	kind, id, err := s.parsePath(u)
+	if err != nil {
+		utils.LogParseFromURLError(session.TelegramID, "failed to parse ID", err, u.String())
+		return nil, err
+	}
+
+	query := url.Values{"language": {session.Lang}, "append_to_response": {"credits"}}
+
+	var media tmdbMedia
+	if err = getDataFromTMDB(app, session, fmt.Sprintf("/%s/%s", kind, id), query, &media); err != nil {
+		return nil, err
+	}
+
+	film := parseFilmFromTMDB(&media)
+	for _, genre := range media.Genres {
+		film.Metadata.Genres = append(film.Metadata.Genres, genre.Name)
+	}
+	return film, nil
+}
+
+// parsePath extracts the media type (movie or tv) and the TMDB ID from the TMDB URL.
+func (tmdbSource) parsePath(u *url.URL) (string, string, error) {
+	segments := pathSegments(u)
+	if len(segments) < 2 || (segments[0] != tmdbKindMovie && segments[0] != tmdbKindSeries) {
+		return "", "", fmt.Errorf("invalid TMDB URL: %s", u)
+	}
+
+	id, _, _ := strings.Cut(segments[1], "-") // The title slug follows the ID.
+	if _, err := strconv.Atoi(id); err != nil {
+		return "", "", fmt.Errorf("invalid TMDB URL: %s", u)
+	}
+	return segments[0], id, nil
+}
+
+// GetFilmsFromTMDB searches films on TMDB by the session's title, page and page size,
+// with titles, descriptions and genres in the session language.
+// TMDB returns fixed pages of 20 results, so they are regrouped into pages of the session's size.
+func GetFilmsFromTMDB(app models.App, session *models.Session) ([]apiModels.Film, *filters.Metadata, error) {
+	state := session.FilmsState
+	pageSize := state.PageSize
+	if pageSize <= 0 {
+		pageSize = 5
+	}
+
+	results, response, err := getTMDBResults((state.CurrentPage-1)*pageSize, pageSize, func(page int) (*tmdbSearchResponse, error) {
+		return searchTMDB(app, session, state.Title, page)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	genres := getTMDBGenres(app, session)
+
+	films := make([]apiModels.Film, 0, len(results))
+	for i := range results {
+		parsed := parseFilmFromTMDB(&results[i])
+		for _, id := range results[i].GenreIDs {
+			if name, ok := genres[id]; ok {
+				parsed.Metadata.Genres = append(parsed.Metadata.Genres, name)
+			}
+		}
+
+		film := parsed.ToFilm(session)
+		film.URL = fmt.Sprintf("%s/%s/%d", tmdbSiteURL, tmdbKindMovie, film.ID)
+		films = append(films, *film)
+	}
+
+	totalRecords := min(response.TotalResults, tmdbMaxPages*tmdbPageSize)
+	metadata := filters.CalculateMetadata(totalRecords, state.CurrentPage, pageSize)
+
+	return films, &metadata, nil
+}
+
+// getTMDBResults returns the results of the page of the size starting at the offset,
+// regrouped from the fixed TMDB pages of 20 results loaded with fetch, and the first loaded TMDB page.
+func getTMDBResults(offset, pageSize int, fetch func(page int) (*tmdbSearchResponse, error)) ([]tmdbMedia, *tmdbSearchResponse, error) {
+	tmdbPage := offset/tmdbPageSize + 1
+	response, err := fetch(tmdbPage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Load the next TMDB pages while the requested page continues there.
+	start := offset % tmdbPageSize
+	results := response.Results
+	for page := tmdbPage; start+pageSize > len(results) && page < response.TotalPages && page < tmdbMaxPages; page++ {
+		next, err := fetch(page + 1)
+		if err != nil {
+			return nil, nil, err
+		}
+		results = append(results, next.Results...)
+	}
+
+	if start >= len(results) {
+		return nil, response, nil
+	}
+	return results[start:min(start+pageSize, len(results))], response, nil
+}
+
+// searchTMDB requests a page of TMDB film search results in the session language.
+func searchTMDB(app models.App, session *models.Session, title string, page int) (*tmdbSearchResponse, error) {
+	query := url.Values{
+		"query":    {title},
+		"page":     {strconv.Itoa(page)},
+		"language": {session.Lang},
+	}
+
+	var response tmdbSearchResponse
+	if err := getDataFromTMDB(app, session, "/search/movie", query, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// getTMDBGenres returns the names of TMDB film genres in the session language keyed by genre ID.
+// The names are cached after the first successful request; on failure an empty map is returned,
+// and the genres are not requested again for a while so that searches are not slowed down by a failing request.
+// The request is sent without holding the cache lock, so it never blocks searches of other users.
+func getTMDBGenres(app models.App, session *models.Session) map[int]string {
+	tmdbGenres.mu.Lock()
+	names, ok := tmdbGenres.names[session.Lang]
+	failedAt := tmdbGenres.failedAt[session.Lang]
+	tmdbGenres.mu.Unlock()
+
+	if ok {
+		return names
+	}
+	if time.Since(failedAt) < tmdbGenresRetryDelay {
+		return map[int]string{}
+	}
+
+	var response struct {
+		Genres []tmdbGenre `json:"genres"`
+	}
+	err := getDataFromTMDB(app, session, "/genre/movie/list", url.Values{"language": {session.Lang}}, &response)
+
+	tmdbGenres.mu.Lock()
+	defer tmdbGenres.mu.Unlock()
+
+	if err != nil {
+		tmdbGenres.failedAt[session.Lang] = time.Now()
+		return map[int]string{}
+	}
+
+	names = make(map[int]string, len(response.Genres))
+	for _, genre := range response.Genres {
+		names[genre.ID] = genre.Name
+	}
+	tmdbGenres.names[session.Lang] = names
+	delete(tmdbGenres.failedAt, session.Lang)
+	return names
+}
+
+// getDataFromTMDB sends an HTTP GET request to the TMDB API with the server-side token
+// and decodes the JSON response into dest.
+func getDataFromTMDB(app models.App, session *models.Session, path string, query url.Values, dest any) error {
+	if app.Config.TMDBAPIToken == "" {
+		return fmt.Errorf("TMDB API token is not configured")
+	}
+
+	resp, err := client.Do(
+		&client.CustomRequest{
+			Context:            app.Context(),
+			Service:            httpclient.External,
+			HeaderType:         client.HeaderAuthorization, // TMDB accepts the API read access token as a bearer token.
+			HeaderValue:        "Bearer " + app.Config.TMDBAPIToken,
+			Method:             http.MethodGet, // HTTP GET method for fetching data.
+			URL:                fmt.Sprintf("%s%s?%s", tmdbAPIURL, path, query.Encode()),
+			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
+			TelegramID:         session.TelegramID,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
+
+	if err = json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
+		return err
+	}
+	return nil
+}
+
+// parseFilmFromTMDB converts a TMDB film or series into a `ParsedFilm` object.
+// The genres are left to the caller, as search results only have genre IDs.
+func parseFilmFromTMDB(media *tmdbMedia) *ParsedFilm {
+	film := &ParsedFilm{
+		Film: apiModels.Film{
+			ID:          media.ID,
+			Title:       firstNonEmpty(media.Title, media.Name, media.OriginalTitle, media.OriginalName, "Unknown"),
+			Year:        parseYearFromDate(firstNonEmpty(media.ReleaseDate, media.FirstAirDate)),
+			Description: media.Overview,
+			Rating:      utils.Round(media.VoteAverage),
+		},
+		Metadata: FilmMetadata{
+			OriginalTitle: firstNonEmpty(media.OriginalTitle, media.OriginalName),
+			Runtime:       media.Runtime,
+			Episodes:      media.NumberOfEpisodes,
+		},
+	}
+	if media.PosterPath != "" {
+		film.Film.ImageURL = tmdbPosterURL + media.PosterPath
+	}
+	if film.Metadata.Runtime == 0 && len(media.EpisodeRunTime) > 0 {
+		film.Metadata.Runtime = media.EpisodeRunTime[0]
+	}
+
+	for _, season := range media.Seasons {
+		if season.SeasonNumber > 0 { // Specials are not a part of the season structure.
+			film.Metadata.Seasons = append(film.Metadata.Seasons, season.EpisodeCount)
+		}
+	}
+	for _, country := range media.ProductionCountries {
+		film.Metadata.Countries = append(film.Metadata.Countries, country.Name)
+	}
+	for _, member := range media.Credits.Crew {
+		if member.Job == "Director" {
+			film.Metadata.Directors = append(film.Metadata.Directors, member.Name)
+		}
+	}
+	for _, actor := range media.Credits.Cast {
+		film.Metadata.Cast = append(film.Metadata.Cast, actor.Name)
+	}
+	return film
+}
+
+// parseYearFromDate extracts the year from a date in the YYYY-MM-DD format.
+func parseYearFromDate(date string) int {
+	if len(date) >= 4 {
+		year, _ := strconv.Atoi(date[:4])
+		return year
+	}
+	return 0
+}
+
+// firstNonEmpty returns the first non-empty string.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+package parsing
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// fakeTMDBSearch returns a fetch function serving fixed TMDB pages of 20 results with IDs counted from 1,
+// and the list of the requested pages.
+func fakeTMDBSearch(totalResults int) (func(page int) (*tmdbSearchResponse, error), *[]int) {
+	var requested []int
+	totalPages := (totalResults + tmdbPageSize - 1) / tmdbPageSize
+
+	return func(page int) (*tmdbSearchResponse, error) {
+		requested = append(requested, page)
+
+		response := &tmdbSearchResponse{Page: page, TotalPages: totalPages, TotalResults: totalResults}
+		for id := (page-1)*tmdbPageSize + 1; id <= min(page*tmdbPageSize, totalResults); id++ {
+			response.Results = append(response.Results, tmdbMedia{ID: id})
+		}
+		return response, nil
+	}, &requested
+}
+
+func TestGetTMDBResultsRegroupsPages(t *testing.T) {
+	tests := []struct {
+		offset, pageSize int
+		wantFirst        int
+		wantLast         int
+		wantPages        []int
+	}{
+		{offset: 0, pageSize: 5, wantFirst: 1, wantLast: 5, wantPages: []int{1}},
+		{offset: 14, pageSize: 7, wantFirst: 15, wantLast: 21, wantPages: []int{1, 2}},     // The page continues on the next TMDB page.
+		{offset: 20, pageSize: 7, wantFirst: 21, wantLast: 27, wantPages: []int{2}},        // The page starts a TMDB page.
+		{offset: 42, pageSize: 7, wantFirst: 43, wantLast: 45, wantPages: []int{3}},        // The last page is shorter.
+		{offset: 10, pageSize: 35, wantFirst: 11, wantLast: 45, wantPages: []int{1, 2, 3}}, // The page spans several TMDB pages.
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("offset %d size %d", test.offset, test.pageSize), func(t *testing.T) {
+			fetch, requested := fakeTMDBSearch(45)
+
+			results, response, err := getTMDBResults(test.offset, test.pageSize, fetch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) == 0 || results[0].ID != test.wantFirst || results[len(results)-1].ID != test.wantLast {
+				t.Fatalf("results = %v, want IDs %d to %d", results, test.wantFirst, test.wantLast)
+			}
+			if len(results) != test.wantLast-test.wantFirst+1 {
+				t.Errorf("got %d results, want %d", len(results), test.wantLast-test.wantFirst+1)
+			}
+			if !slices.Equal(*requested, test.wantPages) {
+				t.Errorf("requested pages %v, want %v", *requested, test.wantPages)
+			}
+			if response.TotalResults != 45 {
+				t.Errorf("total results = %d, want those of the first loaded page", response.TotalResults)
+			}
+		})
+	}
+}
+
+func TestGetTMDBResultsPastTheEnd(t *testing.T) {
+	fetch, _ := fakeTMDBSearch(45)
+
+	results, response, err := getTMDBResults(60, 5, fetch)
+	if err != nil || len(results) != 0 || response == nil {
+		t.Errorf("results = %v, err = %v; want no results and the loaded page", results, err)
+	}
+}
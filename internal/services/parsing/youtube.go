@@ -8,11 +8,13 @@ import (
 	"github.com/k4sper1love/watchlist-bot/internal/models"
 	"github.com/k4sper1love/watchlist-bot/internal/services/client"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"github.com/k4sper1love/watchlist-bot/pkg/httpclient"
 	"github.com/k4sper1love/watchlist-bot/pkg/translator"
 	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
 )
 
@@ -29,17 +31,48 @@ type externalVideoData struct {
 	Deleted     bool    `json:"deleted"`     // Indicates if the video has been deleted.
 }
 
-// GetFilmFromYoutube fetches a YouTube video and parses it into an `models.Film` object.
+// youtubeSource parses YouTube videos as films.
+type youtubeSource struct{}
+
+func init() {
+	Register(youtubeSource{})
+}
+
+// Name returns the name of the YouTube source.
+func (youtubeSource) Name() string {
+	return "youtube"
+}
+
+// MatchHost reports whether the host belongs to YouTube, including short youtu.be links.
+func (youtubeSource) MatchHost(host string) bool {
+	return matchDomain(host, "youtube.com", "youtu.be")
+}
+
+// ExtractID extracts the video ID from a watch or short link.
+func (youtubeSource) ExtractID(u *url.URL) (string, error) {
+	return utils.ExtractYoutubeVideoID(u.String())
+}
+
+// Canonicalize returns the watch URL of the video.
+func (s youtubeSource) Canonicalize(u *url.URL) (*url.URL, error) {
+	videoID, err := s.ExtractID(u)
+	if err != nil {
+		return nil, err
+	}
+	return url.Parse("https://www.youtube.com/watch?v=" + url.QueryEscape(videoID))
+}
+
+// Fetch fetches a YouTube video and parses it into a `ParsedFilm` object without metadata.
 // It extracts the video ID from the URL, fetches video details from the YouTube API,
 // and retrieves additional data from an external API.
-func GetFilmFromYoutube(app models.App, session *models.Session, url string) (*apiModels.Film, error) {
-	videoID, err := utils.ExtractYoutubeVideoID(url)
+func (s youtubeSource) Fetch(app models.App, session *models.Session, u *url.URL) (*ParsedFilm, error) {
+	videoID, err := s.ExtractID(u)
 	if err != nil {
-		utils.LogParseFromURLError(session.TelegramID, "failed to extract video ID", err, url)
+		utils.LogParseFromURLError(session.TelegramID, "failed to extract video ID", err, u.String())
 		return nil, err
 	}
 
-	service, err := youtube.NewService(context.Background(), option.WithAPIKey(app.Config.YoutubeAPIToken))
+	service, err := youtube.NewService(app.Context(), option.WithAPIKey(app.Config.YoutubeAPIToken))
 	if err != nil {
 		slog.Error(
 			"failed to create youtube service",
@@ -49,18 +82,18 @@ func GetFilmFromYoutube(app models.App, session *models.Session, url string) (*a
 		return nil, err
 	}
 
-	video, err := fetchYoutubeVideo(service, videoID)
+	video, err := fetchYoutubeVideo(app.Context(), service, videoID)
 	if err != nil {
 		slog.Error(
 			"failed to fetch youtube video",
 			slog.Any("error", err),
-			slog.String("url", url),
+			slog.String("url", u.String()),
 			slog.Int("telegram_id", session.TelegramID),
 		)
 		return nil, err
 	}
 
-	externalData, err := getExternalVideoData(session, videoID)
+	externalData, err := getExternalVideoData(app, session, videoID)
 	if err != nil {
 		slog.Warn(
 			"failed to get external video data",
@@ -71,12 +104,12 @@ func GetFilmFromYoutube(app models.App, session *models.Session, url string) (*a
 		externalData = &externalVideoData{}
 	}
 
-	return parseVideoFromYoutube(session, video, externalData), nil
+	return &ParsedFilm{Film: *parseVideoFromYoutube(session, video, externalData)}, nil
 }
 
 // fetchYoutubeVideo fetches video details from the YouTube API using the provided video ID.
-func fetchYoutubeVideo(service *youtube.Service, videoID string) (*youtube.Video, error) {
-	resp, err := service.Videos.List([]string{"snippet", "statistics", "contentDetails"}).Id(videoID).Do()
+func fetchYoutubeVideo(ctx context.Context, service *youtube.Service, videoID string) (*youtube.Video, error) {
+	resp, err := service.Videos.List([]string{"snippet", "statistics", "contentDetails"}).Id(videoID).Context(ctx).Do()
 	if err != nil || len(resp.Items) == 0 {
 		return nil, fmt.Errorf("video not found or error occured: %v", err)
 	}
@@ -84,9 +117,11 @@ func fetchYoutubeVideo(service *youtube.Service, videoID string) (*youtube.Video
 }
 
 // getExternalVideoData fetches additional video data (e.g., likes, dislikes) from an external API.
-func getExternalVideoData(session *models.Session, videoID string) (*externalVideoData, error) {
+func getExternalVideoData(app models.App, session *models.Session, videoID string) (*externalVideoData, error) {
 	resp, err := client.Do(
 		&client.CustomRequest{
+			Context:            app.Context(),
+			Service:            httpclient.External,
 			Method:             http.MethodGet, // HTTP GET method for fetching data.
 			URL:                fmt.Sprintf("https://returnyoutubedislikeapi.com/votes?videoId=%s", videoID),
 			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
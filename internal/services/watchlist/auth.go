@@ -1,7 +1,9 @@
 package watchlist
 
 import (
-	"encoding/json"
+	"context"
+	"fmt"
+	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
 	"github.com/k4sper1love/watchlist-api/pkg/tokens"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
 	"github.com/k4sper1love/watchlist-bot/internal/services/client"
@@ -16,82 +18,66 @@ const (
 	verificationTokenExpiration = 10 * time.Second // Duration for which the verification token is valid.
 )
 
+// authResponse is the response of the login and registration endpoints.
+type authResponse struct {
+	Auth apiModels.AuthResponse `json:"user"` // Authenticated user with access and refresh tokens.
+}
+
+// refreshResponse is the response of the token refresh endpoint.
+type refreshResponse struct {
+	AccessToken  string `json:"access_token"`  // New access token.
+	RefreshToken string `json:"refresh_token"` // New refresh token, if the API rotates it.
+}
+
 // Register sends a registration request to the API for a Telegram user.
-func Register(app models.App, session *models.Session) error {
-	return sendAuthRequest(app, session, "/api/v1/auth/register/telegram", http.StatusCreated)
+func (c *WatchlistClient) Register(ctx context.Context, session *models.Session) error {
+	return c.sendAuthRequest(ctx, session, "/api/v1/auth/register/telegram", http.StatusCreated)
 }
 
 // Login sends a login request to the API for a Telegram user.
-func Login(app models.App, session *models.Session) error {
-	return sendAuthRequest(app, session, "/api/v1/auth/login/telegram", http.StatusOK)
+func (c *WatchlistClient) Login(ctx context.Context, session *models.Session) error {
+	return c.sendAuthRequest(ctx, session, "/api/v1/auth/login/telegram", http.StatusOK)
 }
 
 // sendAuthRequest is a helper function to send authentication requests (login or register) to the API.
-// It generates a verification token, sends it in the request headers, and handles the response.
-func sendAuthRequest(app models.App, session *models.Session, endpoint string, expectedStatusCode int) error {
+// It generates a verification token, sends it in the request headers, and stores the received user and tokens in the session.
+func (c *WatchlistClient) sendAuthRequest(ctx context.Context, session *models.Session, endpoint string, expectedStatusCode int) error {
 	// Generate a short-lived verification token for the user.
-	token, err := tokens.GenerateToken(app.Config.APISecret, session.TelegramID, verificationTokenExpiration)
+	token, err := tokens.GenerateToken(c.apiSecret, session.TelegramID, verificationTokenExpiration)
 	if err != nil {
 		slog.Error("failed to generate verification token", slog.Any("error", err), slog.Int("telegram_id", session.TelegramID))
 		return err
 	}
 
 	// Send the authentication request with the verification token.
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderVerification,
-			HeaderValue:        token,
-			Method:             http.MethodPost, // HTTP POST method for creating data.
-			URL:                app.Config.APIHost + endpoint,
-			ExpectedStatusCode: expectedStatusCode,
-			TelegramID:         session.TelegramID,
-		},
-	)
+	resp, err := send[authResponse](ctx, c, session.TelegramID, request{
+		method:         http.MethodPost,
+		path:           endpoint,
+		expectedStatus: expectedStatusCode,
+		headerType:     client.HeaderVerification,
+		headerValue:    token,
+	})
 	if err != nil {
 		return err
 	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
-
-	// Parse the authentication response and populate the session.
-	if err = parseAuth(session, resp.Body); err != nil {
-		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
-		return err
-	}
-
-	return nil
-}
-
-// IsTokenValid checks if the provided access token is valid by sending a verification request to the API.
-func IsTokenValid(app models.App, session *models.Session, encryptedToken string) bool {
-	// Decrypt the encrypted token for use in the request.
-	token, err := security.Decrypt(encryptedToken)
-	if err != nil {
-		utils.LogDecryptError(session.TelegramID, err)
-		return false
+	if resp.Auth.User == nil {
+		return fmt.Errorf("auth response contains no user")
 	}
 
-	// Send a request to the API to verify the token.
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodGet, // HTTP GET method for fetching data.
-			URL:                app.Config.APIHost + "/api/v1/auth/check",
-			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
-			WithoutLog:         true,          // Suppress logging for this request.
-			TelegramID:         session.TelegramID,
-		},
-	)
+	// Encrypt the received tokens for secure storage and populate the session.
+	accessToken, refreshToken, err := encryptTokens(resp.Auth.AccessToken, resp.Auth.RefreshToken)
 	if err != nil {
-		return false
+		utils.LogEncryptError(session.TelegramID, err)
+		return err
 	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
 
-	return true
+	session.User = *resp.Auth.User
+	session.AccessToken, session.RefreshToken = accessToken, refreshToken
+	return nil
 }
 
 // RefreshAccessToken refreshes the access token using the refresh token stored in the session.
-func RefreshAccessToken(app models.App, session *models.Session) error {
+func (c *WatchlistClient) RefreshAccessToken(ctx context.Context, session *models.Session) error {
 	// Decrypt the refresh token for use in the request.
 	token, err := security.Decrypt(session.RefreshToken)
 	if err != nil {
@@ -100,41 +86,36 @@ func RefreshAccessToken(app models.App, session *models.Session) error {
 	}
 
 	// Send a request to the API to refresh the access token.
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodPost, // HTTP POST method for creating data.
-			URL:                app.Config.APIHost + "/api/v1/auth/refresh",
-			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
-			TelegramID:         session.TelegramID,
-		},
-	)
+	resp, err := send[refreshResponse](ctx, c, session.TelegramID, request{
+		method:         http.MethodPost,
+		path:           "/api/v1/auth/refresh",
+		expectedStatus: http.StatusOK,
+		headerType:     client.HeaderAuthorization,
+		headerValue:    token,
+	})
 	if err != nil {
 		return err
 	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
-
-	// Parse the response to extract the new access token.
-	var responseMap map[string]interface{}
-	if err = json.NewDecoder(resp.Body).Decode(&responseMap); err != nil {
-		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
-		return err
+	if resp.AccessToken == "" {
+		return fmt.Errorf("refresh response contains no access token")
 	}
 
-	// Encrypt the new access token and update the session.
-	encryptedAccessToken, err := security.Encrypt(responseMap["access_token"].(string))
+	// Encrypt the new tokens and update the session, keeping the refresh token if the API did not rotate it.
+	accessToken, refreshToken, err := encryptTokens(resp.AccessToken, resp.RefreshToken)
 	if err != nil {
-		utils.LogDecryptError(session.TelegramID, err)
+		utils.LogEncryptError(session.TelegramID, err)
 		return err
 	}
 
-	session.AccessToken = encryptedAccessToken
+	session.AccessToken = accessToken
+	if refreshToken != "" {
+		session.RefreshToken = refreshToken
+	}
 	return nil
 }
 
-// Logout logs out the user by invalidating their refresh token and clearing their session data.
-func Logout(app models.App, session *models.Session) error {
+// Logout logs out the user by invalidating their refresh token.
+func (c *WatchlistClient) Logout(ctx context.Context, session *models.Session) error {
 	// Decrypt the refresh token for use in the request.
 	token, err := security.Decrypt(session.RefreshToken)
 	if err != nil {
@@ -143,20 +124,42 @@ func Logout(app models.App, session *models.Session) error {
 	}
 
 	// Send a request to the API to log out the user.
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodPost, // HTTP POST method for creating data.
-			URL:                app.Config.APIHost + "/api/v1/auth/logout",
-			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
-			TelegramID:         session.TelegramID,
-		},
-	)
-	if err != nil {
-		return err
+	_, err = send[struct{}](ctx, c, session.TelegramID, request{
+		method:         http.MethodPost,
+		path:           "/api/v1/auth/logout",
+		expectedStatus: http.StatusOK,
+		headerType:     client.HeaderAuthorization,
+		headerValue:    token,
+	})
+	return err
+}
+
+// renewTokens refreshes the access token and persists the new tokens.
+// If the refresh token is rejected as well, the user is logged in again.
+func (c *WatchlistClient) renewTokens(ctx context.Context, session *models.Session) error {
+	if err := c.RefreshAccessToken(ctx, session); err != nil {
+		slog.Warn("failed to refresh access token, logging in again", slog.Any("error", err), slog.Int("telegram_id", session.TelegramID))
+		if err = c.Login(ctx, session); err != nil {
+			return err
+		}
 	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
 
+	c.saveTokens(session)
 	return nil
 }
+
+// encryptTokens encrypts the access and refresh tokens. Empty tokens stay empty.
+func encryptTokens(accessToken, refreshToken string) (string, string, error) {
+	encrypted := make([]string, 2)
+	for i, token := range []string{accessToken, refreshToken} {
+		if token == "" {
+			continue
+		}
+
+		var err error
+		if encrypted[i], err = security.Encrypt(token); err != nil {
+			return "", "", err
+		}
+	}
+	return encrypted[0], encrypted[1], nil
+}
@@ -0,0 +1,88 @@
+package watchlist
+
+import (
+	"context"
+	"errors"
+	"github.com/k4sper1love/watchlist-bot/internal/services/client"
+	"github.com/k4sper1love/watchlist-bot/pkg/breaker"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// ErrUnavailable is returned instead of sending requests while the Watchlist API is considered unavailable.
+var ErrUnavailable = errors.New("watchlist API is unavailable")
+
+// IsUnavailable reports whether the error was caused by the Watchlist API being unavailable.
+func IsUnavailable(err error) bool {
+	return errors.Is(err, ErrUnavailable)
+}
+
+// Available reports whether the Watchlist API is available.
+// While it is not, only cached data can be shown and changes must be refused.
+func (c *WatchlistClient) Available() bool {
+	return c.breaker.State() == breaker.Closed
+}
+
+// record reports the outcome of a request to the circuit breaker.
+// Network errors and server errors count as failures, while any response below 500 proves the API is reachable.
+// Requests canceled or timed out by the caller's context, such as on shutdown, say nothing about the API and are ignored.
+func (c *WatchlistClient) record(ctx context.Context, err error) {
+	if err != nil && ctx.Err() != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		c.breaker.Ignore()
+		return
+	}
+	if code := client.StatusCode(err); err != nil && (code == 0 || code >= http.StatusInternalServerError) {
+		c.breaker.Failure()
+		return
+	}
+	c.breaker.Success()
+}
+
+// handleStateChange logs the transitions of the circuit breaker and starts probing once the API becomes unavailable.
+// Changes of availability are reported by the probe, not by the request that caused the transition.
+func (c *WatchlistClient) handleStateChange(from, to breaker.State) {
+	slog.Warn("watchlist API circuit breaker state changed", slog.String("from", from.String()), slog.String("to", to.String()))
+
+	if from == breaker.Closed && to == breaker.Open && c.ctx.Err() == nil && c.probing.CompareAndSwap(false, true) {
+		go c.probe()
+	}
+}
+
+// probe reports that the API became unavailable and periodically checks its health until the circuit breaker closes,
+// then reports that it is available again. It runs in its own goroutine, so the request that opened the breaker
+// does not wait for the admins to be notified, and the bot recovers even if no user sends requests.
+// Probing stops without reporting a recovery once the client's context is done, such as on shutdown.
+func (c *WatchlistClient) probe() {
+	c.onAvailability(false)
+
+	ticker := time.NewTicker(c.probeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			c.probing.Store(false)
+			return
+		case <-ticker.C:
+		}
+
+		if c.Available() {
+			c.onAvailability(true)
+			c.probing.Store(false)
+
+			// The breaker may have opened again after the check without starting a probe, as this one was running.
+			if c.Available() || !c.probing.CompareAndSwap(false, true) {
+				return
+			}
+			c.onAvailability(false)
+			continue
+		}
+
+		_, _ = send[struct{}](c.ctx, c, 0, request{
+			method:         http.MethodGet,
+			path:           "/api/v1/healthcheck",
+			expectedStatus: http.StatusOK,
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package watchlist
+
+import (
+	"sync"
+	"time"
+)
+
+// cacheEntry is a cached API response.
+type cacheEntry struct {
+	value     any       // Decoded response.
+	expiresAt time.Time // Time after which the entry is stale.
+}
+
+// listCache is a per-user read-through cache of list responses.
+// Entries are keyed by the request path, which encodes filters, sorting, page and page size.
+// It is safe for concurrent use.
+type listCache struct {
+	ttl       time.Duration                 // Time to live of the entries. Zero disables the cache.
+	entries   map[int]map[string]cacheEntry // Entries keyed by Telegram ID and request path.
+	lastSweep time.Time                     // Time of the last removal of stale entries.
+	mu        sync.Mutex                    // Mutex guarding the entries.
+}
+
+// newListCache creates a cache with the given time to live.
+func newListCache(ttl time.Duration) *listCache {
+	return &listCache{
+		ttl:     ttl,
+		entries: make(map[int]map[string]cacheEntry),
+	}
+}
+
+// get returns the fresh entry cached for the user under the key.
+func (c *listCache) get(telegramID int, key string) (any, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[telegramID][key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// set caches the value for the user under the key.
+func (c *listCache) set(telegramID int, key string, value any) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(c.lastSweep) > c.ttl {
+		c.sweep(now)
+	}
+
+	if c.entries[telegramID] == nil {
+		c.entries[telegramID] = make(map[string]cacheEntry)
+	}
+	c.entries[telegramID][key] = cacheEntry{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+// invalidate removes all entries of the user.
+// Films and collections reference each other in list responses (e.g., collection films or exclusion filters),
+// so any write may affect every cached list of the user.
+func (c *listCache) invalidate(telegramID int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, telegramID)
+}
+
+// sweep removes stale entries of all users. The caller must hold the mutex.
+func (c *listCache) sweep(now time.Time) {
+	for telegramID, entries := range c.entries {
+		for key, entry := range entries {
+			if now.After(entry.expiresAt) {
+				delete(entries, key)
+			}
+		}
+		if len(entries) == 0 {
+			delete(c.entries, telegramID)
+		}
+	}
+	c.lastSweep = now
+}
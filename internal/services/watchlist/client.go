@@ -0,0 +1,206 @@
+package watchlist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/k4sper1love/watchlist-bot/internal/models"
+	"github.com/k4sper1love/watchlist-bot/internal/services/client"
+	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"github.com/k4sper1love/watchlist-bot/pkg/breaker"
+	"github.com/k4sper1love/watchlist-bot/pkg/httpclient"
+	"github.com/k4sper1love/watchlist-bot/pkg/security"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultOpenTimeout is the interval between probes of an unavailable API used if none is configured.
+const defaultOpenTimeout = 30 * time.Second
+
+// TokenSaver persists the renewed access and refresh tokens of a session.
+type TokenSaver func(session *models.Session)
+
+// Options configures a WatchlistClient.
+type Options struct {
+	Context          context.Context // Context bounding the background work of the client, such as probing (optional).
+	BaseURL          string          // Base URL of the Watchlist API.
+	APISecret        string          // Secret used to sign verification tokens for Telegram login and registration.
+	CacheTTL         time.Duration   // Time for which list responses are cached; zero disables the cache.
+	FailureThreshold int             // Number of consecutive failures after which the API is considered unavailable.
+	OpenTimeout      time.Duration   // Interval between probes of an unavailable API.
+	SaveTokens       TokenSaver      // Function persisting renewed tokens (optional).
+	OnAvailability   func(up bool)   // Function called in the background when the API becomes unavailable or available again (optional).
+}
+
+// WatchlistClient is a client of the Watchlist API.
+// Requests on behalf of a user are authorized with the session's access token,
+// which is renewed transparently when the API rejects it.
+type WatchlistClient struct {
+	ctx            context.Context  // Context bounding the background work of the client.
+	baseURL        string           // Base URL of the Watchlist API.
+	apiSecret      string           // Secret used to sign verification tokens for Telegram login and registration.
+	saveTokens     TokenSaver       // Function persisting renewed tokens.
+	cache          *listCache       // Per-user cache of film and collection lists.
+	breaker        *breaker.Breaker // Circuit breaker failing requests fast while the API is unavailable.
+	probeInterval  time.Duration    // Interval between probes of an unavailable API.
+	onAvailability func(up bool)    // Function called when the availability of the API changes.
+	probing        atomic.Bool      // Whether a probe of the unavailable API is running.
+}
+
+// request describes a single call to the Watchlist API.
+type request struct {
+	method         string // HTTP method (e.g., GET, POST, PUT, DELETE).
+	path           string // Path of the endpoint, including the query string.
+	body           any    // Request body encoded as JSON (optional).
+	expectedStatus int    // Expected HTTP status code for successful responses.
+	headerType     string // Type of the authentication header (optional).
+	headerValue    string // Value of the authentication header.
+}
+
+var (
+	defaultClient *WatchlistClient // Client used by the handlers.
+	mu            sync.RWMutex     // Mutex guarding the default client.
+)
+
+// NewWatchlistClient creates a Watchlist API client.
+func NewWatchlistClient(options Options) *WatchlistClient {
+	if options.OpenTimeout <= 0 {
+		options.OpenTimeout = defaultOpenTimeout
+	}
+
+	c := &WatchlistClient{
+		ctx:            options.Context,
+		baseURL:        options.BaseURL,
+		apiSecret:      options.APISecret,
+		saveTokens:     options.SaveTokens,
+		cache:          newListCache(options.CacheTTL),
+		onAvailability: options.OnAvailability,
+	}
+	if c.ctx == nil {
+		c.ctx = context.Background()
+	}
+	if c.saveTokens == nil {
+		c.saveTokens = func(*models.Session) {}
+	}
+	if c.onAvailability == nil {
+		c.onAvailability = func(bool) {}
+	}
+
+	c.breaker = breaker.New(breaker.Options{
+		FailureThreshold: options.FailureThreshold,
+		OpenTimeout:      options.OpenTimeout,
+		OnStateChange:    c.handleStateChange,
+	})
+	c.probeInterval = options.OpenTimeout
+	return c
+}
+
+// Init sets the client used by the handlers.
+func Init(c *WatchlistClient) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	defaultClient = c
+}
+
+// GetClient returns the client set with Init.
+func GetClient() *WatchlistClient {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return defaultClient
+}
+
+// do sends a request authorized with the session's access token and decodes the response into T.
+// If the API rejects the token, it is renewed once, the new tokens are persisted and the request is retried.
+// Unexpected status codes are returned as *client.APIError.
+// Any request other than GET invalidates the cached lists of the user.
+func do[T any](ctx context.Context, c *WatchlistClient, session *models.Session, method, path string, body any, expectedStatus int) (T, error) {
+	req := request{method: method, path: path, body: body, expectedStatus: expectedStatus}
+
+	if method != http.MethodGet {
+		defer c.cache.invalidate(session.TelegramID)
+	}
+
+	result, err := doAuthorized[T](ctx, c, session, req)
+	if client.StatusCode(err) != http.StatusUnauthorized {
+		return result, err
+	}
+
+	if err = c.renewTokens(ctx, session); err != nil {
+		return result, err
+	}
+	return doAuthorized[T](ctx, c, session, req)
+}
+
+// cached returns the list response cached for the user under the path,
+// fetching it with a GET request and caching it on a miss.
+// The returned value may be shared with other callers and must not be modified in place.
+func cached[T any](ctx context.Context, c *WatchlistClient, session *models.Session, path string) (*T, error) {
+	if value, ok := c.cache.get(session.TelegramID, path); ok {
+		if result, ok := value.(T); ok {
+			return &result, nil
+		}
+	}
+
+	result, err := do[T](ctx, c, session, http.MethodGet, path, nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.set(session.TelegramID, path, result)
+	return &result, nil
+}
+
+// doAuthorized decrypts the session's access token and sends the request with it.
+func doAuthorized[T any](ctx context.Context, c *WatchlistClient, session *models.Session, req request) (T, error) {
+	token, err := security.Decrypt(session.AccessToken)
+	if err != nil {
+		utils.LogDecryptError(session.TelegramID, err)
+		var zero T
+		return zero, err
+	}
+
+	req.headerType, req.headerValue = client.HeaderAuthorization, token
+	return send[T](ctx, c, session.TelegramID, req)
+}
+
+// send sends the request and decodes the JSON response into T.
+// While the API is unavailable, the request is not sent and ErrUnavailable is returned.
+// An empty response body leaves T at its zero value.
+func send[T any](ctx context.Context, c *WatchlistClient, telegramID int, req request) (T, error) {
+	var result T
+
+	if err := c.breaker.Allow(); err != nil {
+		return result, ErrUnavailable
+	}
+
+	resp, err := client.Do(
+		&client.CustomRequest{
+			Context:            ctx,
+			Service:            httpclient.Watchlist,
+			HeaderType:         req.headerType,
+			HeaderValue:        req.headerValue,
+			Method:             req.method,
+			URL:                c.baseURL + req.path,
+			Body:               req.body,
+			ExpectedStatusCode: req.expectedStatus,
+			TelegramID:         telegramID,
+		},
+	)
+	c.record(ctx, err)
+	if err != nil {
+		return result, err
+	}
+	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
+
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil && !errors.Is(err, io.EOF) {
+		utils.LogParseJSONError(telegramID, err, resp.Request.Method, resp.Request.URL.String())
+		return result, err
+	}
+
+	return result, nil
+}
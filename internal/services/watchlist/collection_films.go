@@ -1,159 +1,55 @@
 package watchlist
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
-	"github.com/k4sper1love/watchlist-bot/internal/services/client"
-	"github.com/k4sper1love/watchlist-bot/internal/utils"
-	"github.com/k4sper1love/watchlist-bot/pkg/security"
 	"net/http"
 	"net/url"
 )
 
 // GetCollectionFilms fetches the list of films in a collection from the API.
-// It decrypts the access token, sends a GET request with query parameters for filtering and pagination,
-// and parses the response into a `models.CollectionFilmsResponse` object.
-func GetCollectionFilms(app models.App, session *models.Session) (*models.CollectionFilmsResponse, error) {
-	token, err := security.Decrypt(session.AccessToken)
-	if err != nil {
-		utils.LogDecryptError(session.TelegramID, err)
-		return nil, err
-	}
-
-	// Build the URL with query parameters for filtering and pagination.
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodGet, // HTTP GET method for fetching data.
-			URL:                buildGetCollectionFilmsURL(app, session),
-			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
-			TelegramID:         session.TelegramID,
-		},
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
-
-	// Parse the response into a structured format.
-	var collectionFilmsResponse models.CollectionFilmsResponse
-	if err = json.NewDecoder(resp.Body).Decode(&collectionFilmsResponse); err != nil {
-		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
-		return nil, err
-	}
-
-	return &collectionFilmsResponse, nil
+// It supports filtering and pagination based on the session's state.
+// Responses are served from the per-user cache while fresh.
+func (c *WatchlistClient) GetCollectionFilms(ctx context.Context, session *models.Session) (*models.CollectionFilmsResponse, error) {
+	return cached[models.CollectionFilmsResponse](ctx, c, session, buildGetCollectionFilmsPath(session))
 }
 
-// CreateCollectionFilm creates a new film in a collection by sending a POST request to the API.
-// It decrypts the access token, sends the request with the film details in the body,
-// and parses the response into an `models.CollectionFilm` object.
-func CreateCollectionFilm(app models.App, session *models.Session) (*apiModels.CollectionFilm, error) {
-	token, err := security.Decrypt(session.AccessToken)
-	if err != nil {
-		utils.LogDecryptError(session.TelegramID, err)
-		return nil, err
-	}
+// CreateCollectionFilm creates a new film from the film detail state in the collection selected in the session.
+func (c *WatchlistClient) CreateCollectionFilm(ctx context.Context, session *models.Session) (*apiModels.CollectionFilm, error) {
+	path := fmt.Sprintf("/api/v1/collections/%d/films", session.CollectionDetailState.Collection.ID)
 
-	// Send a POST request to create a new film in the collection.
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodPost, // HTTP POST method for creating data.
-			URL:                fmt.Sprintf("%s/api/v1/collections/%d/films", app.Config.APIHost, session.CollectionDetailState.Collection.ID),
-			Body:               session.FilmDetailState,
-			ExpectedStatusCode: http.StatusCreated, // Expecting a 201 Created response.
-			TelegramID:         session.TelegramID,
-		},
-	)
+	resp, err := do[collectionFilmResponse](ctx, c, session, http.MethodPost, path, session.FilmDetailState, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
-
-	// Parse the response into a structured format.
-	collectionFilm := &apiModels.CollectionFilm{}
-	if err = parseCollectionFilm(collectionFilm, resp.Body); err != nil {
-		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
-		return nil, err
-	}
-
-	return collectionFilm, nil
+	return &resp.CollectionFilm, nil
 }
 
-// AddCollectionFilm adds an existing film to a collection by sending a POST request to the API.
-// It decrypts the access token, sends the request with the film ID and collection ID in the URL,
-// and parses the response into an `models.CollectionFilm` object.
-func AddCollectionFilm(app models.App, session *models.Session) (*apiModels.CollectionFilm, error) {
-	token, err := security.Decrypt(session.AccessToken)
-	if err != nil {
-		utils.LogDecryptError(session.TelegramID, err)
-		return nil, err
-	}
-
-	// Send a POST request to add an existing film to the collection.
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodPost, // HTTP POST method for adding data.
-			URL:                fmt.Sprintf("%s/api/v1/collections/%d/films/%d", app.Config.APIHost, session.CollectionDetailState.Collection.ID, session.FilmDetailState.Film.ID),
-			ExpectedStatusCode: http.StatusCreated, // Expecting a 201 Created response.
-			TelegramID:         session.TelegramID,
-		},
-	)
+// AddCollectionFilm adds the film selected in the session to the selected collection.
+func (c *WatchlistClient) AddCollectionFilm(ctx context.Context, session *models.Session) (*apiModels.CollectionFilm, error) {
+	resp, err := do[collectionFilmResponse](ctx, c, session, http.MethodPost, collectionFilmPath(session), nil, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
-
-	// Parse the response into a structured format.
-	collectionFilm := &apiModels.CollectionFilm{}
-	if err = parseCollectionFilm(collectionFilm, resp.Body); err != nil {
-		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
-		return nil, err
-	}
-
-	return collectionFilm, nil
+	return &resp.CollectionFilm, nil
 }
 
-// DeleteCollectionFilm deletes a film from a collection by sending a DELETE request to the API.
-// It decrypts the access token, sends the request with the film ID and collection ID in the URL,
-// and handles the response.
-func DeleteCollectionFilm(app models.App, session *models.Session) error {
-	token, err := security.Decrypt(session.AccessToken)
-	if err != nil {
-		utils.LogDecryptError(session.TelegramID, err)
-		return err
-	}
-
-	// Send a DELETE request to remove the film from the collection.
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodDelete, // HTTP DELETE method for removing data.
-			URL:                fmt.Sprintf("%s/api/v1/collections/%d/films/%d", app.Config.APIHost, session.CollectionDetailState.Collection.ID, session.FilmDetailState.Film.ID),
-			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
-			TelegramID:         session.TelegramID,
-		},
-	)
-	if err != nil {
-		return err
-	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
+// DeleteCollectionFilm removes the film selected in the session from the selected collection.
+func (c *WatchlistClient) DeleteCollectionFilm(ctx context.Context, session *models.Session) error {
+	_, err := do[struct{}](ctx, c, session, http.MethodDelete, collectionFilmPath(session), nil, http.StatusOK)
+	return err
+}
 
-	return nil
+// collectionFilmPath returns the path of the film selected in the session within the selected collection.
+func collectionFilmPath(session *models.Session) string {
+	return fmt.Sprintf("/api/v1/collections/%d/films/%d", session.CollectionDetailState.Collection.ID, session.FilmDetailState.Film.ID)
 }
 
-// buildGetCollectionFilmsURL constructs the URL for fetching films in a collection.
+// buildGetCollectionFilmsPath constructs the path for fetching films in a collection.
 // It includes query parameters for filtering, sorting, and pagination.
-func buildGetCollectionFilmsURL(app models.App, session *models.Session) string {
-	baseURL := fmt.Sprintf("%s/api/v1/collections/%d/films", app.Config.APIHost, session.CollectionDetailState.ObjectID)
+func buildGetCollectionFilmsPath(session *models.Session) string {
 	state := session.FilmsState
 	queryParams := url.Values{}
 
@@ -163,6 +59,6 @@ func buildGetCollectionFilmsURL(app models.App, session *models.Session) string
 	// Add filter and sorting parameters to the query.
 	queryParams = addFilmsFilterAndSortingParams(queryParams, state.CollectionFilters, state.CollectionSorting)
 
-	// Encode the query parameters and append them to the base URL.
-	return fmt.Sprintf("%s?%s", baseURL, queryParams.Encode())
+	// Encode the query parameters and append them to the path.
+	return fmt.Sprintf("/api/v1/collections/%d/films?%s", session.CollectionDetailState.ObjectID, queryParams.Encode())
 }
@@ -1,165 +1,64 @@
 package watchlist
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
-	"github.com/k4sper1love/watchlist-bot/internal/services/client"
-	"github.com/k4sper1love/watchlist-bot/internal/utils"
-	"github.com/k4sper1love/watchlist-bot/pkg/security"
 	"net/http"
 	"net/url"
 )
 
 // GetCollections fetches the list of collections from the API.
 // It supports pagination and filtering based on the session's state.
-func GetCollections(app models.App, session *models.Session) (*models.CollectionsResponse, error) {
-	return getCollectionsRequest(app, session, -1, -1, session.CollectionsState.CurrentPage, session.CollectionsState.PageSize)
+func (c *WatchlistClient) GetCollections(ctx context.Context, session *models.Session) (*models.CollectionsResponse, error) {
+	return c.getCollections(ctx, session, -1, -1, session.CollectionsState.CurrentPage, session.CollectionsState.PageSize)
 }
 
 // GetCollectionsExcludeFilm fetches the list of collections excluding a specific film.
 // It supports pagination and filtering based on the session's state.
-func GetCollectionsExcludeFilm(app models.App, session *models.Session) (*models.CollectionsResponse, error) {
-	return getCollectionsRequest(app, session, -1, session.FilmDetailState.Film.ID, session.CollectionFilmsState.CurrentPage, session.CollectionFilmsState.PageSize)
+func (c *WatchlistClient) GetCollectionsExcludeFilm(ctx context.Context, session *models.Session) (*models.CollectionsResponse, error) {
+	return c.getCollections(ctx, session, -1, session.FilmDetailState.Film.ID, session.CollectionFilmsState.CurrentPage, session.CollectionFilmsState.PageSize)
 }
 
-// getCollectionsRequest is a helper function to send requests for fetching collections.
-// It constructs the URL with query parameters for filtering, sorting, and pagination,
-// decrypts the access token, and parses the response into a `models.CollectionsResponse` object.
-func getCollectionsRequest(app models.App, session *models.Session, filmID, excludeFilmID, currentPage, pageSize int) (*models.CollectionsResponse, error) {
-	token, err := security.Decrypt(session.AccessToken)
-	if err != nil {
-		utils.LogDecryptError(session.TelegramID, err)
-		return nil, err
-	}
-
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodGet, // HTTP GET method for fetching data.
-			URL:                buildGetCollectionsURL(app, session, filmID, excludeFilmID, currentPage, pageSize),
-			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
-			TelegramID:         session.TelegramID,
-		},
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
-
-	var collectionsResponse models.CollectionsResponse
-	if err = json.NewDecoder(resp.Body).Decode(&collectionsResponse); err != nil {
-		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
-		return nil, err
-	}
-
-	return &collectionsResponse, nil
+// getCollections is a helper function to send requests for fetching collections.
+// Responses are served from the per-user cache while fresh.
+func (c *WatchlistClient) getCollections(ctx context.Context, session *models.Session, filmID, excludeFilmID, currentPage, pageSize int) (*models.CollectionsResponse, error) {
+	return cached[models.CollectionsResponse](ctx, c, session, buildGetCollectionsPath(session, filmID, excludeFilmID, currentPage, pageSize))
 }
 
-// CreateCollection creates a new collection by sending a POST request to the API.
-// It decrypts the access token, sends the request with the collection details in the body,
-// and parses the response into an `models.Collection` object.
-func CreateCollection(app models.App, session *models.Session) (*apiModels.Collection, error) {
-	token, err := security.Decrypt(session.AccessToken)
-	if err != nil {
-		utils.LogDecryptError(session.TelegramID, err)
-		return nil, err
-	}
-
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodPost, // HTTP POST method for creating data.
-			URL:                app.Config.APIHost + "/api/v1/collections",
-			Body:               session.CollectionDetailState,
-			ExpectedStatusCode: http.StatusCreated, // Expecting a 201 Created response.
-			TelegramID:         session.TelegramID,
-		},
-	)
+// CreateCollection creates a new collection from the collection detail state of the session.
+func (c *WatchlistClient) CreateCollection(ctx context.Context, session *models.Session) (*apiModels.Collection, error) {
+	resp, err := do[collectionResponse](ctx, c, session, http.MethodPost, "/api/v1/collections", session.CollectionDetailState, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
-
-	collection := &apiModels.Collection{}
-	if err = parseCollection(collection, resp.Body); err != nil {
-		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
-		return nil, err
-	}
-
-	return collection, nil
+	return &resp.Collection, nil
 }
 
-// UpdateCollection updates an existing collection by sending a PUT request to the API.
-// It decrypts the access token, sends the request with the updated collection details in the body,
-// and parses the response into an `models.Collection` object.
-func UpdateCollection(app models.App, session *models.Session) (*apiModels.Collection, error) {
-	token, err := security.Decrypt(session.AccessToken)
-	if err != nil {
-		utils.LogDecryptError(session.TelegramID, err)
-		return nil, err
-	}
-
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodPut, // HTTP PUT method for updating data.
-			URL:                fmt.Sprintf("%s/api/v1/collections/%d", app.Config.APIHost, session.CollectionDetailState.Collection.ID),
-			Body:               session.CollectionDetailState,
-			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
-			TelegramID:         session.TelegramID,
-		},
-	)
+// UpdateCollection updates the collection selected in the session with its detail state.
+func (c *WatchlistClient) UpdateCollection(ctx context.Context, session *models.Session) (*apiModels.Collection, error) {
+	resp, err := do[collectionResponse](ctx, c, session, http.MethodPut, collectionPath(session), session.CollectionDetailState, http.StatusOK)
 	if err != nil {
 		return nil, err
 	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
-
-	collection := &apiModels.Collection{}
-	if err = parseCollection(collection, resp.Body); err != nil {
-		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
-		return nil, err
-	}
-
-	return collection, nil
+	return &resp.Collection, nil
 }
 
-// DeleteCollection deletes a collection by sending a DELETE request to the API.
-// It decrypts the access token, sends the request with the collection ID in the URL,
-// and handles the response.
-func DeleteCollection(app models.App, session *models.Session) error {
-	token, err := security.Decrypt(session.AccessToken)
-	if err != nil {
-		utils.LogDecryptError(session.TelegramID, err)
-		return err
-	}
-
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodDelete, // HTTP DELETE method for removing data.
-			URL:                fmt.Sprintf("%s/api/v1/collections/%d", app.Config.APIHost, session.CollectionDetailState.Collection.ID),
-			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
-			TelegramID:         session.TelegramID,
-		},
-	)
-	if err != nil {
-		return err
-	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
+// DeleteCollection deletes the collection selected in the session.
+func (c *WatchlistClient) DeleteCollection(ctx context.Context, session *models.Session) error {
+	_, err := do[struct{}](ctx, c, session, http.MethodDelete, collectionPath(session), nil, http.StatusOK)
+	return err
+}
 
-	return nil
+// collectionPath returns the path of the collection selected in the session.
+func collectionPath(session *models.Session) string {
+	return fmt.Sprintf("/api/v1/collections/%d", session.CollectionDetailState.Collection.ID)
 }
 
-// buildGetCollectionsURL constructs the URL for fetching collections.
+// buildGetCollectionsPath constructs the path for fetching collections.
 // It includes query parameters for filtering, sorting, and pagination.
-func buildGetCollectionsURL(app models.App, session *models.Session, filmID, excludeFilmID, currentPage, pageSize int) string {
-	baseURL := fmt.Sprintf("%s/api/v1/collections", app.Config.APIHost)
+func buildGetCollectionsPath(session *models.Session, filmID, excludeFilmID, currentPage, pageSize int) string {
 	queryParams := url.Values{}
 
 	// Add optional query parameters if they are provided.
@@ -182,6 +81,6 @@ func buildGetCollectionsURL(app models.App, session *models.Session, filmID, exc
 		queryParams.Add("sort", session.CollectionsState.Sorting.Sort)
 	}
 
-	// Encode the query parameters and append them to the base URL.
-	return fmt.Sprintf("%s?%s", baseURL, queryParams.Encode())
+	// Encode the query parameters and append them to the path.
+	return fmt.Sprintf("/api/v1/collections?%s", queryParams.Encode())
 }
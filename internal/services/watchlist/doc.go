@@ -1,6 +1,12 @@
-// Package watchlist provides utilities for interacting with the Watchlist API.
+// Package watchlist provides a client for the Watchlist API.
 //
-// It includes functions for managing users, films, collections, and other entities,
-// as well as handling authentication, image uploads, and data retrieval.
-// This package ensures a consistent and reliable integration with the Watchlist API.
+// WatchlistClient manages users, films, collections, and other entities,
+// as well as authentication and image uploads. Requests are sent through the generic
+// do helper, which authorizes them with the session's access token, renews expired tokens,
+// decodes the JSON response and returns unexpected status codes as typed API errors.
+// Film and collection lists are cached per user for a short time and invalidated by any write.
+//
+// A circuit breaker tracks the availability of the API. After repeated network or server errors,
+// requests fail fast with ErrUnavailable, the API is probed in the background, and the client
+// reports when it becomes unavailable and available again, so the bot can switch to read-only mode.
 package watchlist
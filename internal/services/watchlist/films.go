@@ -1,198 +1,73 @@
 package watchlist
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	apiModels "github.com/k4sper1love/watchlist-api/pkg/models"
 	"github.com/k4sper1love/watchlist-bot/internal/models"
-	"github.com/k4sper1love/watchlist-bot/internal/services/client"
-	"github.com/k4sper1love/watchlist-bot/internal/utils"
-	"github.com/k4sper1love/watchlist-bot/pkg/security"
 	"net/http"
 	"net/url"
 )
 
 // GetFilms fetches a paginated list of films from the API.
 // It supports filtering and pagination based on the session's state.
-func GetFilms(app models.App, session *models.Session) (*models.FilmsResponse, error) {
-	return getFilmsRequest(app, session, -1, session.FilmsState.CurrentPage, session.FilmsState.PageSize)
+func (c *WatchlistClient) GetFilms(ctx context.Context, session *models.Session) (*models.FilmsResponse, error) {
+	return c.getFilms(ctx, session, -1, session.FilmsState.CurrentPage, session.FilmsState.PageSize)
 }
 
 // GetFilmsExcludeCollection fetches a paginated list of films excluding those in a specific collection.
 // It supports filtering and pagination based on the session's state.
-func GetFilmsExcludeCollection(app models.App, session *models.Session) (*models.FilmsResponse, error) {
-	return getFilmsRequest(app, session, session.CollectionDetailState.Collection.ID, session.CollectionFilmsState.CurrentPage, session.CollectionFilmsState.PageSize)
+func (c *WatchlistClient) GetFilmsExcludeCollection(ctx context.Context, session *models.Session) (*models.FilmsResponse, error) {
+	return c.getFilms(ctx, session, session.CollectionDetailState.Collection.ID, session.CollectionFilmsState.CurrentPage, session.CollectionFilmsState.PageSize)
 }
 
-// getFilmsRequest is a helper function to send requests for fetching films.
-// It constructs the URL with query parameters for filtering, sorting, and pagination,
-// decrypts the access token, and parses the response into a `models.FilmsResponse` object.
-func getFilmsRequest(app models.App, session *models.Session, collectionID, currentPage, pageSize int) (*models.FilmsResponse, error) {
-	token, err := security.Decrypt(session.AccessToken)
-	if err != nil {
-		utils.LogDecryptError(session.TelegramID, err)
-		return nil, err
-	}
-
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodGet, // HTTP GET method for fetching data.
-			URL:                buildGetFilmsURL(app, session, collectionID, currentPage, pageSize),
-			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
-			TelegramID:         session.TelegramID,
-		},
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
-
-	var filmsResponse models.FilmsResponse
-	if err = json.NewDecoder(resp.Body).Decode(&filmsResponse); err != nil {
-		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
-		return nil, err
-	}
-
-	return &filmsResponse, nil
+// getFilms is a helper function to send requests for fetching films.
+// Responses are served from the per-user cache while fresh.
+func (c *WatchlistClient) getFilms(ctx context.Context, session *models.Session, collectionID, currentPage, pageSize int) (*models.FilmsResponse, error) {
+	return cached[models.FilmsResponse](ctx, c, session, buildGetFilmsPath(session, collectionID, currentPage, pageSize))
 }
 
-// GetFilm fetches a single film by its ID from the API.
-// It decrypts the access token, sends the request, and parses the response into an `models.Film` object.
-func GetFilm(app models.App, session *models.Session) (*apiModels.Film, error) {
-	token, err := security.Decrypt(session.AccessToken)
+// GetFilm fetches the film selected in the session by its ID.
+func (c *WatchlistClient) GetFilm(ctx context.Context, session *models.Session) (*apiModels.Film, error) {
+	resp, err := do[filmResponse](ctx, c, session, http.MethodGet, filmPath(session), nil, http.StatusOK)
 	if err != nil {
-		utils.LogDecryptError(session.TelegramID, err)
 		return nil, err
 	}
-
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodGet, // HTTP GET method for fetching data.
-			URL:                fmt.Sprintf("%s/api/v1/films/%d", app.Config.APIHost, session.FilmDetailState.Film.ID),
-			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
-			TelegramID:         session.TelegramID,
-		},
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
-
-	film := &apiModels.Film{}
-	if err = parseFilm(film, resp.Body); err != nil {
-		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
-		return nil, err
-	}
-
-	return film, nil
+	return &resp.Film, nil
 }
 
-// UpdateFilm updates an existing film by sending a PUT request to the API.
-// It decrypts the access token, sends the request with updated film details in the body,
-// and parses the response into an `models.Film` object.
-func UpdateFilm(app models.App, session *models.Session) (*apiModels.Film, error) {
-	token, err := security.Decrypt(session.AccessToken)
+// UpdateFilm updates the film selected in the session with its detail state.
+func (c *WatchlistClient) UpdateFilm(ctx context.Context, session *models.Session) (*apiModels.Film, error) {
+	resp, err := do[filmResponse](ctx, c, session, http.MethodPut, filmPath(session), session.FilmDetailState, http.StatusOK)
 	if err != nil {
-		utils.LogDecryptError(session.TelegramID, err)
 		return nil, err
 	}
-
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodPut, // HTTP PUT method for updating data.
-			URL:                fmt.Sprintf("%s/api/v1/films/%d", app.Config.APIHost, session.FilmDetailState.Film.ID),
-			Body:               session.FilmDetailState,
-			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
-			TelegramID:         session.TelegramID,
-		},
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
-
-	film := &apiModels.Film{}
-	if err = parseFilm(film, resp.Body); err != nil {
-		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
-		return nil, err
-	}
-
-	return film, nil
+	return &resp.Film, nil
 }
 
-// CreateFilm creates a new film by sending a POST request to the API.
-// It decrypts the access token, sends the request with the film details in the body,
-// and parses the response into an `apiModels.Film` object.
-func CreateFilm(app models.App, session *models.Session) (*apiModels.Film, error) {
-	token, err := security.Decrypt(session.AccessToken)
-	if err != nil {
-		utils.LogDecryptError(session.TelegramID, err)
-		return nil, err
-	}
-
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodPost, // HTTP POST method for creating data.
-			URL:                app.Config.APIHost + "/api/v1/films",
-			Body:               session.FilmDetailState,
-			ExpectedStatusCode: http.StatusCreated, // Expecting a 201 Created response.
-			TelegramID:         session.TelegramID,
-		},
-	)
+// CreateFilm creates a new film from the film detail state of the session.
+func (c *WatchlistClient) CreateFilm(ctx context.Context, session *models.Session) (*apiModels.Film, error) {
+	resp, err := do[filmResponse](ctx, c, session, http.MethodPost, "/api/v1/films", session.FilmDetailState, http.StatusCreated)
 	if err != nil {
 		return nil, err
 	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
-
-	film := &apiModels.Film{}
-	if err = parseFilm(film, resp.Body); err != nil {
-		utils.LogParseJSONError(session.TelegramID, err, resp.Request.Method, resp.Request.URL.String())
-		return nil, err
-	}
-
-	return film, nil
+	return &resp.Film, nil
 }
 
-// DeleteFilm deletes a film by sending a DELETE request to the API.
-// It decrypts the access token, sends the request with the film ID in the URL,
-// and handles the response.
-func DeleteFilm(app models.App, session *models.Session) error {
-	token, err := security.Decrypt(session.AccessToken)
-	if err != nil {
-		utils.LogDecryptError(session.TelegramID, err)
-		return err
-	}
-
-	resp, err := client.Do(
-		&client.CustomRequest{
-			HeaderType:         client.HeaderAuthorization,
-			HeaderValue:        token,
-			Method:             http.MethodDelete, // HTTP DELETE method for removing data.
-			URL:                fmt.Sprintf("%s/api/v1/films/%d", app.Config.APIHost, session.FilmDetailState.Film.ID),
-			ExpectedStatusCode: http.StatusOK, // Expecting a 200 OK response.
-			TelegramID:         session.TelegramID,
-		},
-	)
-	if err != nil {
-		return err
-	}
-	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
+// DeleteFilm deletes the film selected in the session.
+func (c *WatchlistClient) DeleteFilm(ctx context.Context, session *models.Session) error {
+	_, err := do[struct{}](ctx, c, session, http.MethodDelete, filmPath(session), nil, http.StatusOK)
+	return err
+}
 
-	return nil
+// filmPath returns the path of the film selected in the session.
+func filmPath(session *models.Session) string {
+	return fmt.Sprintf("/api/v1/films/%d", session.FilmDetailState.Film.ID)
 }
 
-// buildGetFilmsURL constructs the URL for fetching films.
+// buildGetFilmsPath constructs the path for fetching films.
 // It includes query parameters for filtering, sorting, and pagination.
-func buildGetFilmsURL(app models.App, session *models.Session, collectionID, currentPage, pageSize int) string {
-	baseURL := fmt.Sprintf("%s/api/v1/films", app.Config.APIHost)
+func buildGetFilmsPath(session *models.Session, collectionID, currentPage, pageSize int) string {
 	state := session.FilmsState
 	queryParams := url.Values{}
 
@@ -204,8 +79,8 @@ func buildGetFilmsURL(app models.App, session *models.Session, collectionID, cur
 	queryParams = addFilmsBasicParams(queryParams, state.Title, currentPage, pageSize)
 	queryParams = addFilmsFilterAndSortingParams(queryParams, state.FilmFilters, state.FilmSorting)
 
-	// Encode the query parameters and append them to the base URL.
-	return fmt.Sprintf("%s?%s", baseURL, queryParams.Encode())
+	// Encode the query parameters and append them to the path.
+	return fmt.Sprintf("/api/v1/films?%s", queryParams.Encode())
 }
 
 // addFilmsBasicParams adds basic query parameters (title, page, page size) to the URL.
@@ -2,10 +2,11 @@ package watchlist
 
 import (
 	"bytes"
+	"context"
 	"fmt"
-	"github.com/k4sper1love/watchlist-bot/internal/models"
 	"github.com/k4sper1love/watchlist-bot/internal/services/client"
 	"github.com/k4sper1love/watchlist-bot/internal/utils"
+	"github.com/k4sper1love/watchlist-bot/pkg/httpclient"
 	"io"
 	"log/slog"
 	"mime/multipart"
@@ -14,30 +15,38 @@ import (
 
 // UploadImage uploads an image to the API and returns the URL of the uploaded image.
 // It prepares a multipart/form-data request, sends it to the API, and parses the response.
-func UploadImage(app models.App, data []byte) (string, error) {
+// The request is sent on behalf of the user with the given Telegram ID.
+func (c *WatchlistClient) UploadImage(ctx context.Context, telegramID int, data []byte) (string, error) {
 	// Prepare the multipart/form-data request for uploading the image.
-	req, err := prepareImageRequest(app, data)
+	req, err := c.prepareImageRequest(ctx, data)
 	if err != nil {
 		slog.Error("failed to prepare request", slog.Any("error", err))
 		return "", err
 	}
 
-	// Send the request to the API.
-	resp, err := client.SendRequest(int(app.GetChatID()), req)
+	// Send the request to the API unless it is unavailable.
+	if err = c.breaker.Allow(); err != nil {
+		return "", ErrUnavailable
+	}
+
+	resp, err := client.SendRequest(telegramID, httpclient.Watchlist, req)
 	if err != nil {
+		c.record(ctx, err)
 		return "", err
 	}
 	defer utils.CloseBody(resp.Body) // Ensure the response body is closed after use.
 
 	// Check if the response status code indicates success.
-	if resp.StatusCode != 201 {
-		return "", utils.LogResponseError(int(app.GetChatID()), req.URL.String(), req.Method, 201, resp.StatusCode, resp.Status)
+	err = client.CheckResponse(telegramID, resp, http.StatusCreated, false)
+	c.record(ctx, err)
+	if err != nil {
+		return "", err
 	}
 
 	// Parse the response to extract the image URL.
 	imageURL, err := parseImageURL(resp.Body)
 	if err != nil {
-		utils.LogParseJSONError(int(app.GetChatID()), err, resp.Request.Method, resp.Request.URL.String())
+		utils.LogParseJSONError(telegramID, err, resp.Request.Method, resp.Request.URL.String())
 		return "", err
 	}
 
@@ -46,7 +55,7 @@ func UploadImage(app models.App, data []byte) (string, error) {
 
 // prepareImageRequest prepares a multipart/form-data HTTP request for uploading an image.
 // It creates a form file with the provided image data and sets the appropriate headers.
-func prepareImageRequest(app models.App, data []byte) (*http.Request, error) {
+func (c *WatchlistClient) prepareImageRequest(ctx context.Context, data []byte) (*http.Request, error) {
 	body := new(bytes.Buffer) // Buffer to hold the multipart/form-data body.
 	writer := multipart.NewWriter(body)
 
@@ -69,7 +78,7 @@ func prepareImageRequest(app models.App, data []byte) (*http.Request, error) {
 	}
 
 	// Create the HTTP POST request with the multipart/form-data body.
-	request, err := http.NewRequest(http.MethodPost, app.Config.APIHost+"/upload", body)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/upload", body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/k4sper1love/watchlist-bot/internal/handlers/states"
+	"html"
 	"io"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -245,6 +247,15 @@ func SplitTextByLength(text string, maxLength int) (string, string) {
 	return firstPart, secondPart
 }
 
+// htmlTagRegexp matches the HTML tags supported by Telegram in HTML parse mode.
+var htmlTagRegexp = regexp.MustCompile(`</?(b|strong|i|em|u|ins|s|strike|del|code|pre|a|span|tg-spoiler|blockquote)(\s[^>]*)?>`)
+
+// StripHTML removes Telegram HTML tags from the text and unescapes HTML entities.
+// It is used to resend a message as plain text when Telegram rejects its markup.
+func StripHTML(text string) string {
+	return html.UnescapeString(htmlTagRegexp.ReplaceAllString(text, ""))
+}
+
 // LastIndexRune finds the last occurrence of a target rune in a slice of runes within a given range.
 // It returns the index of the rune or -1 if the rune is not found.
 func LastIndexRune(runes []rune, maxLength int, target rune) int {
@@ -393,6 +404,16 @@ func NumberToEmoji(number int) string {
 	return result
 }
 
+// ProgressToBar converts a progress into a bar of ten cells (e.g., 3 of 10 -> "▰▰▰▱▱▱▱▱▱▱").
+func ProgressToBar(done, total int) string {
+	const cells = 10
+	filled := 0
+	if total > 0 {
+		filled = min(max(done, 0)*cells/total, cells)
+	}
+	return strings.Repeat("▰", filled) + strings.Repeat("▱", cells-filled)
+}
+
 // CloseBody ensures that an HTTP response body is closed safely.
 // It logs a warning if an error occurs during closing.
 func CloseBody(body io.ReadCloser) {
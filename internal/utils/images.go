@@ -1,8 +1,10 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/k4sper1love/watchlist-bot/pkg/httpclient"
 	"io"
 	"log/slog"
 	"net/http"
@@ -20,35 +22,56 @@ var supportedTypes = map[string]bool{
 	"image/gif; charset=UTF-8":  true,
 }
 
+// maxImageBytes is the maximum size of a downloaded image, the limit of photos sent to Telegram.
+const maxImageBytes = 10 << 20
+
+// errImageTooLarge is returned when a downloaded image exceeds maxImageBytes.
+var errImageTooLarge = fmt.Errorf("image exceeds %d bytes", maxImageBytes)
+
+// FileURLGetter resolves download URLs of files uploaded to Telegram.
+type FileURLGetter interface {
+	GetFileURL(fileID string) (string, error)
+}
+
 // ParseImageFromMessage extracts an image from a Telegram message.
 // It handles both direct photo messages and URLs provided in the message text.
-func ParseImageFromMessage(bot *tgbotapi.BotAPI, update *tgbotapi.Update) ([]byte, error) {
+func ParseImageFromMessage(ctx context.Context, getter FileURLGetter, update *tgbotapi.Update) ([]byte, error) {
 	// Check if the message contains a photo.
 	if update.Message == nil || update.Message.Photo == nil {
-		return ParseImageFromURL(ParseMessageString(update))
+		return ParseImageFromURL(ctx, ParseMessageString(update))
 	}
 
 	// Get the largest available photo size.
 	photo := (*update.Message.Photo)[len(*update.Message.Photo)-1]
 
-	// Fetch the file metadata from Telegram's API.
-	file, err := bot.GetFile(tgbotapi.FileConfig{FileID: photo.FileID})
+	// Resolve the download URL of the file and parse the image.
+	fileURL, err := getter.GetFileURL(photo.FileID)
 	if err != nil {
 		slog.Error("failed to get file", slog.Any("error", err))
 		return nil, err
 	}
 
-	// Construct the full URL for the file and parse the image.
-	fileURL := fmt.Sprintf("https://api.telegram.org/file/bot%s/%s", bot.Token, file.FilePath)
-	return ParseImageFromURL(fileURL)
+	return ParseImageFromURL(ctx, fileURL)
 }
 
 // ParseImageFromURL fetches an image from a given URL.
 // It checks the content type to ensure it is supported and reads the image data.
-func ParseImageFromURL(imageURL string) ([]byte, error) {
-	resp, err := http.Get(imageURL)
+func ParseImageFromURL(ctx context.Context, imageURL string) ([]byte, error) {
+	return parseImage(ctx, httpclient.Images, imageURL)
+}
+
+// ParsePublicImageFromURL fetches an image from a URL chosen by an arbitrary page, such as its og:image.
+// Unlike ParseImageFromURL, it only connects to public addresses.
+func ParsePublicImageFromURL(ctx context.Context, imageURL string) ([]byte, error) {
+	return parseImage(ctx, httpclient.Public, imageURL)
+}
+
+// parseImage fetches an image from a given URL with the client of the service.
+// It checks the content type to ensure it is supported and reads at most maxImageBytes of the image data.
+func parseImage(ctx context.Context, service, imageURL string) ([]byte, error) {
+	resp, err := getImage(ctx, service, imageURL)
 	if err != nil {
-		slog.Error("failed to get image by URL", slog.Any("error", err), slog.String("url", imageURL))
+		slog.Error("failed to get image by URL", slog.Any("error", err), slog.String("url", RedactSecrets(imageURL)))
 		return nil, err
 	}
 	defer CloseBody(resp.Body) // Ensure the response body is closed after use.
@@ -58,15 +81,18 @@ func ParseImageFromURL(imageURL string) ([]byte, error) {
 		slog.Warn(
 			"image has unsupported content type",
 			slog.String("content-type", resp.Header.Get("Content-Type")),
-			slog.String("url", imageURL),
+			slog.String("url", RedactSecrets(imageURL)),
 		)
 		return nil, fmt.Errorf("unsupported content type: %s", resp.Header.Get("Content-Type"))
 	}
 
 	// Read the image data from the response body.
-	data, err := io.ReadAll(resp.Body)
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err == nil && len(data) > maxImageBytes {
+		err = errImageTooLarge
+	}
 	if err != nil {
-		slog.Error("failed to read response body", slog.Any("error", err), slog.String("url", imageURL))
+		slog.Error("failed to read response body", slog.Any("error", err), slog.String("url", RedactSecrets(imageURL)))
 		return nil, err
 	}
 
@@ -75,10 +101,10 @@ func ParseImageFromURL(imageURL string) ([]byte, error) {
 
 // DownloadImage downloads an image from a URL and saves it as a temporary file.
 // It returns the path to the temporary file.
-func DownloadImage(imageURL string) (string, error) {
-	resp, err := http.Get(imageURL)
+func DownloadImage(ctx context.Context, imageURL string) (string, error) {
+	resp, err := getImage(ctx, httpclient.Images, imageURL)
 	if err != nil {
-		slog.Error("failed to get image by URL", slog.Any("error", err), slog.String("url", imageURL))
+		slog.Error("failed to get image by URL", slog.Any("error", err), slog.String("url", RedactSecrets(imageURL)))
 		return "", err
 	}
 	defer CloseBody(resp.Body) // Ensure the response body is closed after use.
@@ -86,21 +112,33 @@ func DownloadImage(imageURL string) (string, error) {
 	// Create a temporary file to store the image.
 	file, err := os.CreateTemp("", "image_*.jpg")
 	if err != nil {
-		slog.Error("failed to create temporary file", slog.Any("error", err), slog.String("url", imageURL))
+		slog.Error("failed to create temporary file", slog.Any("error", err), slog.String("url", RedactSecrets(imageURL)))
 		return "", err
 	}
 	defer CloseFile(file) // Ensure the file is closed after use.
 
 	// Copy the image data into the temporary file.
-	_, err = io.Copy(file, resp.Body)
+	written, err := io.Copy(file, io.LimitReader(resp.Body, maxImageBytes+1))
+	if err == nil && written > maxImageBytes {
+		err = errImageTooLarge
+	}
 	if err != nil {
-		slog.Error("failed to copy image data to file", slog.Any("error", err), slog.String("url", imageURL))
+		slog.Error("failed to copy image data to file", slog.Any("error", err), slog.String("url", RedactSecrets(imageURL)))
 		return "", err
 	}
 
 	return file.Name(), nil
 }
 
+// getImage sends a GET request for the image using the shared client of the service.
+func getImage(ctx context.Context, service, imageURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return httpclient.For(service).Do(req)
+}
+
 // isSupportedImageType checks if the given content type is supported.
 func isSupportedImageType(contentType string) bool {
 	return supportedTypes[contentType]
@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"fmt"
 	"log/slog"
 )
 
@@ -9,34 +8,34 @@ import (
 func LogRequestError(telegramID int, message string, err error, method, requestURL string) {
 	slog.Error(
 		message,
-		slog.Any("error", err),
+		slog.String("error", RedactSecrets(err.Error())),
 		slog.String("method", method),
-		slog.String("url", requestURL),
+		slog.String("url", RedactSecrets(requestURL)),
 		slog.Int("telegram_id", telegramID))
 }
 
 // LogRequestDebug logs a debug-level message for an outgoing HTTP request.
+// Secrets such as API keys in the URL are redacted.
 func LogRequestDebug(telegramID int, method, requestURL string) {
 	slog.Debug(
 		"send request",
 		slog.String("method", method),
-		slog.String("url", requestURL),
+		slog.String("url", RedactSecrets(requestURL)),
 		slog.Int("telegram_id", telegramID))
 }
 
-// LogResponseError logs an error related to an HTTP response.
-// Returns an error with a formatted message for further handling.
-func LogResponseError(telegramID int, url, method string, expectedCode, code int, status string) error {
+// LogResponseError logs an error related to an HTTP response with an unexpected status code.
+func LogResponseError(telegramID int, method, url string, expectedCode, code int, status, body string) {
 	slog.Error(
 		"failed response",
 		slog.String("method", method),
-		slog.String("url", url),
+		slog.String("url", RedactSecrets(url)),
 		slog.Int("expected_code", expectedCode),
 		slog.Int("code", code),
 		slog.String("status", status),
+		slog.String("body", RedactSecrets(body)),
 		slog.Int("telegram_id", telegramID),
 	)
-	return fmt.Errorf("failed response from %s with code %d", url, code)
 }
 
 // LogParseJSONError logs an error that occurs while parsing JSON data.
@@ -45,7 +44,7 @@ func LogParseJSONError(telegramID int, err error, method, requestURL string) {
 		"failed parsing",
 		slog.Any("error", err),
 		slog.String("method", method),
-		slog.String("url", requestURL),
+		slog.String("url", RedactSecrets(requestURL)),
 		slog.Int("telegram_id", telegramID))
 }
 
@@ -77,6 +76,14 @@ func LogMessageError(err error, chatID int64, messageID int) {
 		slog.Int64("telegram_id", chatID))
 }
 
+// LogDeleteMessageError logs an error when a message cannot be deleted.
+func LogDeleteMessageError(err error, chatID int64, messageID int) {
+	slog.Error("error when deleting message",
+		slog.Any("error", err),
+		slog.Int("message_id", messageID),
+		slog.Int64("telegram_id", chatID))
+}
+
 // LogRemoveFileWarn logs a warning when a file cannot be removed.
 func LogRemoveFileWarn(err error, path string) {
 	slog.Warn(
@@ -137,7 +144,7 @@ func LogParseLanguagesError(err error, dir string) {
 func LogParseFromURLError(telegramID int, message string, err error, url string) {
 	slog.Error(
 		message,
-		slog.Any("error", err),
-		slog.String("url", url),
+		slog.String("error", RedactSecrets(err.Error())),
+		slog.String("url", RedactSecrets(url)),
 		slog.Int("telegram_id", telegramID))
 }
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"github.com/k4sper1love/watchlist-bot/internal/handlers/states"
+	"regexp"
+)
+
+// redactedValue replaces secrets in logged text.
+const redactedValue = "[REDACTED]"
+
+// secretStates lists the session states in which user input is a secret and must never be logged.
+var secretStates = map[string]bool{
+	states.AwaitSettingsKinopoiskToken: true,
+	states.AwaitNewFilmKinopoiskToken:  true,
+}
+
+// secretPatterns match secrets that may appear in logged text, such as URLs or messages.
+// Each pattern is replaced using its replacement template.
+var secretPatterns = []struct {
+	regexp      *regexp.Regexp
+	replacement string
+}{
+	// Query parameters carrying API keys or tokens, e.g. the OMDb "apikey=" parameter.
+	{regexp.MustCompile(`(?i)([?&](?:api_?key|key|token|access_token|refresh_token|secret)=)[^&#\s]+`), "${1}" + redactedValue},
+	// JSON Web Tokens.
+	{regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`), redactedValue},
+	// Telegram bot tokens, e.g. in file download URLs.
+	{regexp.MustCompile(`\b\d{6,12}:[A-Za-z0-9_-]{30,}`), redactedValue},
+	// Kinopoisk API tokens.
+	{regexp.MustCompile(`\b[A-Z0-9]{7}-[A-Z0-9]{7}-[A-Z0-9]{7}-[A-Z0-9]{7}\b`), redactedValue},
+	// Email addresses.
+	{regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), redactedValue},
+}
+
+// MaskSecret hides all but the last four characters of the secret, e.g. "****abcd".
+func MaskSecret(secret string) string {
+	const visible = 4
+	runes := []rune(secret)
+	if len(runes) <= visible {
+		return "****"
+	}
+	return "****" + string(runes[len(runes)-visible:])
+}
+
+// IsSecretState reports whether the user input expected in the given session state is a secret.
+func IsSecretState(state string) bool {
+	return secretStates[state]
+}
+
+// RedactInput masks the user input entirely if the session awaits a secret; otherwise, it redacts known secret patterns.
+func RedactInput(state, input string) string {
+	if IsSecretState(state) && input != "" {
+		return redactedValue
+	}
+	return RedactSecrets(input)
+}
+
+// RedactSecrets replaces API keys, tokens, JWTs and email addresses in the text with a placeholder.
+func RedactSecrets(text string) string {
+	for _, pattern := range secretPatterns {
+		text = pattern.regexp.ReplaceAllString(text, pattern.replacement)
+	}
+	return text
+}
@@ -0,0 +1,152 @@
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// State is the state of a circuit breaker.
+type State int
+
+// States of a circuit breaker.
+const (
+	Closed   State = iota // Requests are let through.
+	Open                  // Requests are rejected.
+	HalfOpen              // A single probe request is let through.
+)
+
+// ErrOpen is returned by Allow while the breaker rejects requests.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// Options configures the behaviour of a Breaker.
+type Options struct {
+	FailureThreshold int                  // Number of consecutive failures that opens the breaker.
+	OpenTimeout      time.Duration        // Time after which an open breaker lets a probe request through.
+	OnStateChange    func(from, to State) // Function called after every state transition (optional).
+}
+
+// Breaker is a circuit breaker. It is safe for concurrent use.
+type Breaker struct {
+	options  Options    // Threshold, timeout and state change callback.
+	state    State      // Current state.
+	failures int        // Number of consecutive failures while closed.
+	openedAt time.Time  // Time the breaker was last opened.
+	probing  bool       // Whether a probe request is in flight while half-open.
+	mu       sync.Mutex // Mutex guarding the state.
+}
+
+// New creates a closed breaker. Non-positive options are replaced by defaults of 5 failures and 30 seconds.
+func New(options Options) *Breaker {
+	if options.FailureThreshold <= 0 {
+		options.FailureThreshold = 5
+	}
+	if options.OpenTimeout <= 0 {
+		options.OpenTimeout = 30 * time.Second
+	}
+	return &Breaker{options: options}
+}
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// State returns the current state of the breaker.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// Allow reports whether a request may be sent, returning ErrOpen if not.
+// Every allowed request must be followed by a call to Success, Failure or Ignore.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+
+	switch b.state {
+	case Open:
+		if time.Since(b.openedAt) < b.options.OpenTimeout {
+			b.mu.Unlock()
+			return ErrOpen
+		}
+		b.probing = true
+		b.transition(HalfOpen) // Unlocks the mutex.
+		return nil
+
+	case HalfOpen:
+		defer b.mu.Unlock()
+		if b.probing {
+			return ErrOpen
+		}
+		b.probing = true
+		return nil
+
+	default:
+		b.mu.Unlock()
+		return nil
+	}
+}
+
+// Success records a successful request, closing the breaker if it was not closed.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+
+	b.failures = 0
+	b.probing = false
+	if b.state == Closed {
+		b.mu.Unlock()
+		return
+	}
+	b.transition(Closed) // Unlocks the mutex.
+}
+
+// Failure records a failed request, opening the breaker once the threshold is reached
+// or immediately if the failed request was a probe.
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+
+	b.failures++
+	b.probing = false
+	if b.state == Open || (b.state == Closed && b.failures < b.options.FailureThreshold) {
+		b.mu.Unlock()
+		return
+	}
+
+	b.openedAt = time.Now()
+	b.transition(Open) // Unlocks the mutex.
+}
+
+// Ignore records a request whose outcome says nothing about the service, such as one canceled by its caller.
+// It neither counts as a failure nor resets them, and only lets another probe through if the request was one.
+func (b *Breaker) Ignore() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// transition switches the breaker to the state, unlocks the mutex and calls the state change callback.
+// The caller must hold the mutex.
+func (b *Breaker) transition(to State) {
+	from := b.state
+	b.state = to
+	if to == Closed {
+		b.failures = 0
+	}
+	b.mu.Unlock()
+
+	if b.options.OnStateChange != nil {
+		b.options.OnStateChange(from, to)
+	}
+}
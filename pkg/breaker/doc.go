@@ -0,0 +1,21 @@
+// Package breaker provides a circuit breaker protecting the bot from an unavailable service.
+//
+// The breaker starts closed and lets every request through. After a configured number of
+// consecutive failures it opens and rejects requests with ErrOpen, so callers fail fast instead
+// of waiting for timeouts. Once the open timeout elapses, the breaker becomes half-open and lets
+// a single probe request through: its success closes the breaker, its failure opens it again.
+// Requests whose outcome says nothing about the service, such as canceled ones, are ignored.
+//
+// Usage:
+//
+//	b := breaker.New(breaker.Options{FailureThreshold: 5, OpenTimeout: 30 * time.Second})
+//
+//	if err := b.Allow(); err != nil {
+//		return err
+//	}
+//	if err := call(); err != nil {
+//		b.Failure()
+//		return err
+//	}
+//	b.Success()
+package breaker
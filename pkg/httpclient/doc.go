@@ -0,0 +1,20 @@
+// Package httpclient provides shared HTTP clients for the external services used by the bot.
+//
+// All clients share a single pooled transport, so connections to the same host are reused
+// across requests and users. Each service has its own timeout, and requests with idempotent
+// methods are retried with exponential backoff and jitter on network errors and on
+// 429, 502, 503 and 504 responses.
+//
+// Pages of arbitrary sites requested by users are fetched with the Public client, whose transport
+// refuses to connect to loopback, private, link-local, multicast and reserved addresses, including after redirects.
+//
+// Requests carry their context through every attempt, so canceling the context of an
+// update aborts its outbound calls, including the waits between retries.
+//
+// Usage:
+//
+//	httpclient.Configure(httpclient.Kinopoisk, httpclient.Options{Timeout: 10 * time.Second, MaxAttempts: 3})
+//
+//	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+//	resp, err := httpclient.For(httpclient.Kinopoisk).Do(req)
+package httpclient
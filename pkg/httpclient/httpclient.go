@@ -0,0 +1,297 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Names of the services with separately configured clients.
+const (
+	Watchlist = "watchlist" // Watchlist API.
+	Kinopoisk = "kinopoisk" // Kinopoisk API.
+	External  = "external"  // Other external APIs and scraped film pages.
+	Images    = "images"    // Hosts of posters and uploaded images.
+	Public    = "public"    // Pages of arbitrary sites requested by users, only fetched from public addresses.
+)
+
+// ErrNonPublicAddress is returned when a client of the Public service is asked to connect to a non-public address.
+var ErrNonPublicAddress = errors.New("connection to a non-public address refused")
+
+// maxDrainBytes is the maximum number of bytes read from a discarded response body to reuse its connection.
+const maxDrainBytes = 4 << 10
+
+// Options configures the behaviour of a Client.
+type Options struct {
+	Timeout        time.Duration // Timeout of a single attempt, including reading the response body.
+	MaxAttempts    int           // Maximum number of attempts for idempotent requests.
+	RetryBaseDelay time.Duration // Delay before the first retry, doubled for every following retry.
+}
+
+// Client sends HTTP requests with a timeout and retries idempotent requests that failed transiently.
+type Client struct {
+	http    *http.Client // Underlying HTTP client using the shared transport.
+	options Options      // Timeout and retry settings.
+}
+
+var (
+	transport       = newTransport()                                          // Pooled transport shared by all clients.
+	publicTransport = newPublicTransport()                                    // Pooled transport of the Public service.
+	fallback        = New(Options{Timeout: 30 * time.Second, MaxAttempts: 1}) // Client used for unconfigured services.
+	mu              sync.RWMutex                                              // Mutex guarding the clients map.
+
+	// clients holds the configured clients keyed by service name.
+	// The Public client is always present, so its requests never fall back to a client without address checks.
+	clients = map[string]*Client{
+		Public: newClient(Public, Options{Timeout: 30 * time.Second, MaxAttempts: 1}),
+	}
+)
+
+// newTransport creates the pooled transport shared by all clients.
+func newTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// newPublicTransport creates the pooled transport of the Public service.
+// It connects directly, without a proxy, and refuses non-public addresses when dialing,
+// so the check also applies to the hosts of redirects and to host names resolving to such addresses.
+func newPublicTransport() *http.Transport {
+	public := newTransport()
+	public.Proxy = nil
+	public.DialContext = (&net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   checkPublicAddress,
+	}).DialContext
+	return public
+}
+
+// nonPublicPrefixes lists the ranges that are global unicast by the standard library
+// but still reach internal networks or are not routable on the internet.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "This network".
+	netip.MustParsePrefix("100.64.0.0/10"),  // Shared address space of carrier-grade NAT, used for metadata by some clouds.
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF protocol assignments.
+	netip.MustParsePrefix("198.18.0.0/15"),  // Benchmarking.
+	netip.MustParsePrefix("240.0.0.0/4"),    // Reserved, including the broadcast address.
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64, mapping to any IPv4 address.
+	netip.MustParsePrefix("64:ff9b:1::/48"), // Local-use NAT64.
+	netip.MustParsePrefix("2002::/16"),      // 6to4, embedding any IPv4 address.
+	netip.MustParsePrefix("2001::/32"),      // Teredo, embedding any IPv4 address.
+}
+
+// IsPublicIP reports whether the address is a global unicast address outside private and reserved ranges.
+func IsPublicIP(ip netip.Addr) bool {
+	ip = ip.Unmap() // IPv4 addresses mapped to IPv6 are checked as IPv4.
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkPublicAddress refuses connections to addresses that are not public, as reported by IsPublicIP.
+func checkPublicAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, ip)
+	}
+	return nil
+}
+
+// New creates a client with the given options using the shared transport.
+func New(options Options) *Client {
+	if options.MaxAttempts < 1 {
+		options.MaxAttempts = 1
+	}
+	return &Client{
+		http:    &http.Client{Transport: transport, Timeout: options.Timeout},
+		options: options,
+	}
+}
+
+// Configure sets the options of the client used for the service.
+func Configure(service string, options Options) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	clients[service] = newClient(service, options)
+}
+
+// newClient creates the client of the service; the client of the Public service only connects to public addresses.
+func newClient(service string, options Options) *Client {
+	c := New(options)
+	if service == Public {
+		c.http.Transport = publicTransport
+	}
+	return c
+}
+
+// For returns the client configured for the service, or a default client if the service is not configured.
+func For(service string) *Client {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if c, ok := clients[service]; ok {
+		return c
+	}
+	return fallback
+}
+
+// Do sends the request, retrying it if its method is idempotent and the attempt failed transiently.
+// The request's context bounds all attempts and the waits between them.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	attempts := 1
+	if isRetryable(req) {
+		attempts = c.options.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq, err := prepareAttempt(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.http.Do(attemptReq)
+		if attempt >= attempts || !shouldRetry(req.Context(), resp, err) {
+			return resp, err
+		}
+
+		delay := c.backoff(attempt)
+		slog.Warn(
+			"retrying request",
+			slog.String("method", req.Method),
+			slog.String("host", req.URL.Host),
+			slog.Int("attempt", attempt),
+			slog.String("delay", delay.String()),
+			slog.String("reason", retryReason(resp, err)),
+		)
+		discard(resp)
+
+		if err = sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// prepareAttempt returns the request to send for the given attempt, rewinding its body for retries.
+func prepareAttempt(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	attemptReq := req.Clone(req.Context())
+	attemptReq.Body = body
+	return attemptReq, nil
+}
+
+// isRetryable reports whether the request may be sent more than once.
+// Only idempotent methods with a rewindable body are retried.
+func isRetryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	default:
+		return false
+	}
+}
+
+// shouldRetry reports whether the attempt failed transiently and the context still allows another one.
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ErrNonPublicAddress) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryReason describes why the attempt is retried, leaving out the request URL of transport errors.
+func retryReason(resp *http.Response, err error) string {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err.Error() // The URL may contain secrets, such as API keys or the bot token.
+	}
+	if err != nil {
+		return err.Error()
+	}
+	return resp.Status
+}
+
+// backoff returns the delay before the next attempt: the base delay doubled for every retry, with equal jitter.
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.options.RetryBaseDelay << (attempt - 1)
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// discard drains and closes the body of a response that will not be returned, so its connection can be reused.
+func discard(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	_, _ = io.CopyN(io.Discard, resp.Body, maxDrainBytes)
+	_ = resp.Body.Close()
+}
+
+// sleep waits for the delay or until the context is done.
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
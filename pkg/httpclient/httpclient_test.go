@@ -0,0 +1,47 @@
+package httpclient
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckPublicAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		public  bool
+	}{
+		{"93.184.216.34:443", true},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:443", true},
+		{"127.0.0.1:80", false},
+		{"[::1]:80", false},
+		{"10.0.0.5:80", false},
+		{"172.16.0.1:80", false},
+		{"192.168.1.1:80", false},
+		{"169.254.169.254:80", false},
+		{"100.100.100.200:80", false},
+		{"0.0.0.0:80", false},
+		{"0.1.2.3:80", false},
+		{"224.0.0.251:5353", false},
+		{"239.1.2.3:80", false},
+		{"198.18.0.1:80", false},
+		{"255.255.255.255:80", false},
+		{"[::]:80", false},
+		{"[fc00::1]:80", false},
+		{"[fe80::1]:80", false},
+		{"[ff0e::1]:80", false},
+		{"[::ffff:127.0.0.1]:80", false},
+		{"[::ffff:10.0.0.1]:80", false},
+		{"[64:ff9b::a9fe:a9fe]:80", false},
+		{"[2002:7f00:1::]:80", false},
+	}
+
+	for _, tt := range tests {
+		err := checkPublicAddress("tcp", tt.address, nil)
+		if tt.public && err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.address, err)
+		}
+		if !tt.public && !errors.Is(err, ErrNonPublicAddress) {
+			t.Errorf("%s: error = %v, want ErrNonPublicAddress", tt.address, err)
+		}
+	}
+}
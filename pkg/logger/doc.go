@@ -45,6 +45,7 @@
 //
 // - Loggers are stored in a global map keyed by user ID.
 // - If a logger does not exist for a user, it is created dynamically.
+// - Call `Close()` on shutdown to close all open log files.
 //
 // This package simplifies logging in multi-user applications, ensuring organized and manageable log files.
 package logger
@@ -1,8 +1,10 @@
 package logger
 
 import (
+	"errors"
 	"fmt"
 	"github.com/natefinch/lumberjack"
+	"io"
 	"log"
 	"os"
 	"sync"
@@ -12,6 +14,7 @@ import (
 // It uses a mutex to ensure safe concurrent access to the underlying logger.
 type Wrapper struct {
 	logger *log.Logger // The underlying logger instance.
+	file   io.Closer   // The rotating log file backing the logger.
 	mu     sync.Mutex  // Mutex to ensure thread-safe operations.
 }
 
@@ -56,6 +59,7 @@ func Get(userID int) *Wrapper {
 	// Create a new logger with the specified settings.
 	logger = &Wrapper{
 		logger: log.New(lumberjackLogger, "", log.Ldate|log.Ltime|log.Lmsgprefix),
+		file:   lumberjackLogger,
 	}
 
 	// Store the logger in the global map and return it.
@@ -95,6 +99,24 @@ func (w *Wrapper) Println(msg string) {
 	w.logger.Println(msg)
 }
 
+// Close closes the log files of all created loggers and removes them from the global map.
+// Loggers retrieved afterward with Get open their files again.
+func Close() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	var errs []error
+	for userID, logger := range loggers {
+		logger.mu.Lock()
+		errs = append(errs, logger.file.Close())
+		logger.mu.Unlock()
+
+		delete(loggers, userID)
+	}
+
+	return errors.Join(errs...)
+}
+
 // GetFilePath constructs the log file path for a user based on their ID.
 // It checks if the log file exists and returns an error if it does not.
 func GetFilePath(userID int) (string, error) {